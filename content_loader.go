@@ -5,43 +5,50 @@ import "fmt"
 type contentLoader struct {
 	loader   *Loader
 	filename string
+	includes *[]Location
+	source   sourceText
 	target   interface{}
 }
 
-func (c *contentLoader) fixNodeLocation(node, parent *Node) {
-	if !isCommentsFixed(node) {
-		fixNodeComment(node, c.filename)
-	}
+// fixNodeLocation track ``node`` and its children, nodes that are created by tags and are not tracked yet
+// belong to the file of their parent. ``source`` is nil for nodes that are not read from the source.
+func (c *contentLoader) fixNodeLocation(node *Node, path string, parent Location, source sourceText) {
+	loc := c.loader.provenance.Track(node, parent.Filename, path, parent.includes, source)
 
 	if node.Kind == SequenceNode {
 		for i, ch := range node.Content {
-			fixNodeComment(ch, c.filename)
-			ch.LineComment = fmt.Sprintf("%s[%d]", node.LineComment, i)
-			c.fixNodeLocation(ch, node)
+			c.fixNodeLocation(ch, fmt.Sprintf("%s[%d]", path, i), loc, source)
 		}
 	} else if node.Kind == MappingNode {
 		for i := 0; i < len(node.Content); i += 2 {
-			fixNodeComment(node.Content[i], c.filename)
-			node.Content[i].LineComment = ""
-
-			fixNodeComment(node.Content[i+1], c.filename)
-			node.Content[i+1].LineComment = fmt.Sprintf("%s.%s", node.LineComment, node.Content[i].Value)
-
-			c.fixNodeLocation(node.Content[i+1], node)
+			c.loader.provenance.Track(node.Content[i], loc.Filename, "", loc.includes, source)
+			c.fixNodeLocation(node.Content[i+1], fmt.Sprintf("%s.%s", path, node.Content[i].Value), loc, source)
 		}
 	}
 }
 
+// Location return location of the file that is loaded by ``c``
+func (c *contentLoader) Location() Location {
+	return Location{Filename: c.filename, includes: c.includes}
+}
+
 func (c *contentLoader) UnmarshalYAML(node *Node) error {
 	// first of all fix location of the node
-	c.fixNodeLocation(node, nil)
+	c.fixNodeLocation(node, "", c.Location(), c.source)
 
 	var err error
 	node, err = c.loader.ResolveTags(node)
 	if err != nil {
 		return err
+	} else if node == nil {
+		return nil
+	}
+	// possibly fix location of any added node, they are not written in the source
+	c.fixNodeLocation(node, "", c.Location(), nil)
+	err = c.loader.decode(node, c.target)
+	c.loader.locateErrors(err)
+	if err != nil && c.loader.collectErrors {
+		return c.loader.recordError(node, err)
 	}
-	// possibly fix location of any added node
-	c.fixNodeLocation(node, nil)
-	return node.Decode(c.target)
+	return err
 }
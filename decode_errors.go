@@ -0,0 +1,97 @@
+package yaml
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var (
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	linePrefix      = regexp.MustCompile(`^line \d+: `)
+)
+
+// decodeNode decode ``node`` into ``target`` and if it fails, return a ``YamlErrors`` with an error for
+// each node that failed to decode.
+func (loader *Loader) decodeNode(node *Node, target interface{}) error {
+	err := node.Decode(target)
+	if err == nil {
+		return nil
+	}
+
+	if errs := loader.locateDecodeErrors(node, reflect.TypeOf(target)); len(errs) != 0 {
+		return errs
+	}
+	return NewYamlError(node, err)
+}
+
+// locateDecodeErrors walk ``node`` and ``typ`` together and find the deepest nodes that fail to decode
+func (loader *Loader) locateDecodeErrors(node *Node, typ reflect.Type) YamlErrors {
+	err := node.Decode(reflect.New(typ).Interface())
+	if err == nil {
+		return nil
+	}
+
+	var errs YamlErrors
+	base := indirectType(typ)
+	value := UnwrapNode(node)
+	if !reflect.PtrTo(base).Implements(unmarshalerType) {
+		switch {
+		case value.Kind == MappingNode && base.Kind() == reflect.Struct:
+			errs = loader.locateStructDecodeErrors(value, base)
+
+		case value.Kind == MappingNode && base.Kind() == reflect.Map:
+			for i := 0; i < len(value.Content); i += 2 {
+				errs = append(errs, loader.locateDecodeErrors(value.Content[i], base.Key())...)
+				errs = append(errs, loader.locateDecodeErrors(value.Content[i+1], base.Elem())...)
+			}
+
+		case value.Kind == SequenceNode && (base.Kind() == reflect.Slice || base.Kind() == reflect.Array):
+			for _, item := range value.Content {
+				errs = append(errs, loader.locateDecodeErrors(item, base.Elem())...)
+			}
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	var typeErr *TypeError
+	if !errors.As(err, &typeErr) {
+		// other errors(like length of arrays or errors of custom unmarshalers) stop the decoder, so they
+		// belong to the deepest node that reproduce them
+		if msg := err.Error(); strings.HasPrefix(msg, "yaml: ") {
+			err = &messageError{message: strings.TrimPrefix(msg, "yaml: "), err: Err_Decode}
+		}
+		return YamlErrors{{Location: loader.NodeLocation(node), Err: err}}
+	}
+	for _, msg := range typeErr.Errors {
+		errs = append(errs, &YamlError{
+			Location: loader.NodeLocation(node),
+			Err:      &messageError{message: linePrefix.ReplaceAllString(msg, ""), err: Err_Decode},
+		})
+	}
+	return errs
+}
+
+func (loader *Loader) locateStructDecodeErrors(node *Node, typ reflect.Type) YamlErrors {
+	info, err := getStructInfo(typ)
+	if err != nil {
+		return nil
+	}
+
+	var errs YamlErrors
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			errs = append(errs, loader.locateDecodeErrors(value, typ)...)
+		} else if field := info.FieldByKey(key.Value); field != nil {
+			errs = append(errs, loader.locateDecodeErrors(value, field.Field.Type)...)
+		} else if info.InlineMap != nil {
+			errs = append(errs, loader.locateDecodeErrors(value, indirectType(typ.FieldByIndex(info.InlineMap).Type).Elem())...)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,157 @@
+package yaml
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// findMissingRequired walk ``node`` and ``typ`` together and report every field that is tagged with
+// ``required:"true"`` and is missing from the mapping that is decoded into its struct, errors are
+// reported at the enclosing mapping.
+func (loader *Loader) findMissingRequired(node *Node, typ reflect.Type) YamlErrors {
+	if typ == nil {
+		return nil
+	}
+
+	base := indirectType(typ)
+	if reflect.PtrTo(base).Implements(unmarshalerType) {
+		return nil
+	}
+
+	var errs YamlErrors
+	value := UnwrapNode(node)
+	switch {
+	case value.Kind == MappingNode && base.Kind() == reflect.Struct:
+		info, err := getStructInfo(base)
+		if err != nil {
+			return nil
+		}
+
+		present := map[string]bool{}
+		for _, pair := range mappingPairs(value) {
+			present[pair[0].Value] = true
+			if field := info.FieldByKey(pair[0].Value); field != nil {
+				errs = append(errs, loader.findMissingRequired(pair[1], field.Field.Type)...)
+			} else if info.InlineMap != nil {
+				errs = append(errs, loader.findMissingRequired(pair[1],
+					indirectType(base.FieldByIndex(info.InlineMap).Type).Elem())...)
+			}
+		}
+		for _, field := range info.Fields {
+			if field.Required && !present[field.Key] {
+				errs = append(errs, &YamlError{
+					Location: loader.NodeLocation(value),
+					Err:      fmt.Errorf("missing required field(%s): %w", field.Key, Err_MissingRequiredNode),
+				})
+			}
+		}
+
+	case value.Kind == MappingNode && base.Kind() == reflect.Map:
+		for i := 0; i < len(value.Content); i += 2 {
+			errs = append(errs, loader.findMissingRequired(value.Content[i+1], base.Elem())...)
+		}
+
+	case value.Kind == SequenceNode && (base.Kind() == reflect.Slice || base.Kind() == reflect.Array):
+		for _, item := range value.Content {
+			errs = append(errs, loader.findMissingRequired(item, base.Elem())...)
+		}
+	}
+	return errs
+}
+
+// applyDefaults set fields of structs in ``value`` that are tagged with ``default`` and are missing from
+// ``node``(the node that ``value`` is decoded from), values of the tag are decoded as yaml. ``node`` is nil
+// when the whole value is missing, so defaults of nested structs are applied too, in that case errors are
+// reported at ``site``, the nearest node that exists.
+func (loader *Loader) applyDefaults(value reflect.Value, node, site *Node) error {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if node != nil {
+		node = UnwrapNode(node)
+		site = node
+	}
+	if reflect.PtrTo(value.Type()).Implements(unmarshalerType) {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return loader.applyStructDefaults(value, node, site)
+
+	case reflect.Slice, reflect.Array:
+		if node == nil || node.Kind != SequenceNode {
+			return nil
+		}
+		for i := 0; i < value.Len() && i < len(node.Content); i++ {
+			if err := loader.applyDefaults(value.Index(i), node.Content[i], site); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		if node == nil || node.Kind != MappingNode || indirectType(value.Type().Elem()).Kind() != reflect.Struct {
+			return nil
+		}
+		// items of a map are not addressable, so a copy of each item is updated and stored back
+		for _, pair := range mappingPairs(node) {
+			key := reflect.New(value.Type().Key())
+			if err := pair[0].Decode(key.Interface()); err != nil {
+				continue
+			}
+			item := value.MapIndex(key.Elem())
+			if !item.IsValid() {
+				continue
+			}
+			updated := reflect.New(item.Type()).Elem()
+			updated.Set(item)
+			if err := loader.applyDefaults(updated, pair[1], site); err != nil {
+				return err
+			}
+			value.SetMapIndex(key.Elem(), updated)
+		}
+	}
+	return nil
+}
+
+func (loader *Loader) applyStructDefaults(value reflect.Value, node, site *Node) error {
+	if !value.CanAddr() {
+		return nil
+	}
+
+	info, err := getStructInfo(value.Type())
+	if err != nil {
+		return err
+	}
+
+	values := map[string]*Node{}
+	if node != nil && node.Kind == MappingNode {
+		for _, pair := range mappingPairs(node) {
+			values[pair[0].Value] = pair[1]
+		}
+	}
+
+	for _, field := range info.Fields {
+		fv := fieldByIndex(value, field.Index)
+		if !fv.IsValid() {
+			continue
+		}
+
+		if child, ok := values[field.Key]; ok {
+			if err = loader.applyDefaults(fv, child, site); err != nil {
+				return err
+			}
+		} else if field.Default != nil {
+			if err = UnmarshalYaml([]byte(*field.Default), fv.Addr().Interface()); err != nil {
+				return NewYamlErrorf(site, "invalid default value(%s) of field %s: %w", *field.Default,
+					field.Key, Err_InvalidValue)
+			}
+		} else if err = loader.applyDefaults(fv, nil, site); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package yaml
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// DiagnosticLocation is the serializable form of a ``Location``, ``Path`` is a dotted path without the
+// leading dot(``servers[0].port``).
+type DiagnosticLocation struct {
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	EndLine   int    `json:"endLine,omitempty"`
+	EndColumn int    `json:"endColumn,omitempty"`
+	Path      string `json:"path,omitempty"`
+}
+
+// Diagnostic is a machine readable form of an error of the loader, it is used to report errors to editors
+// and CI systems.
+type Diagnostic struct {
+	DiagnosticLocation
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	// IncludedFrom is location of include nodes that lead to the file of the error, outermost first
+	IncludedFrom []DiagnosticLocation `json:"includedFrom,omitempty"`
+}
+
+// NewDiagnostics convert ``err`` to a list of diagnostics, ``YamlErrors`` are expanded and errors without
+// a location are reported without a file.
+func NewDiagnostics(err error) []Diagnostic {
+	if err == nil {
+		return nil
+	}
+
+	errs := flattenErrors(err)
+	diagnostics := make([]Diagnostic, len(errs))
+	for i, e := range errs {
+		diagnostics[i] = Diagnostic{
+			DiagnosticLocation: newDiagnosticLocation(e.Location),
+			Code:               GetErrorCode(e.Err),
+			Message:            e.Err.Error(),
+		}
+		for _, site := range e.IncludeChain() {
+			diagnostics[i].IncludedFrom = append(diagnostics[i].IncludedFrom, newDiagnosticLocation(site))
+		}
+	}
+	return diagnostics
+}
+
+func newDiagnosticLocation(loc Location) DiagnosticLocation {
+	return DiagnosticLocation{
+		File:      loc.Filename,
+		Line:      loc.Line,
+		Column:    loc.Column,
+		EndLine:   loc.EndLine,
+		EndColumn: loc.EndColumn,
+		Path:      strings.TrimPrefix(loc.Path, "."),
+	}
+}
+
+// MarshalDiagnostics serialize diagnostics of ``err`` as a JSON array, see ``NewDiagnostics``.
+func MarshalDiagnostics(err error) ([]byte, error) {
+	diagnostics := NewDiagnostics(err)
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	return json.MarshalIndent(diagnostics, "", "  ")
+}
+
+// SARIF 2.1.0 log, only the parts that are required to report results are defined
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules,omitempty"`
+}
+type sarifRule struct {
+	ID string `json:"id"`
+}
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+	// RelatedLocations are include nodes that lead to the file of the result
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+}
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+	Message          *sarifMessage          `json:"message,omitempty"`
+}
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+func (d DiagnosticLocation) sarifLocation() (sarifLocation, bool) {
+	var loc sarifLocation
+	if d.File != "" {
+		loc.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: d.File}}
+		if d.Line > 0 {
+			loc.PhysicalLocation.Region = &sarifRegion{
+				StartLine:   d.Line,
+				StartColumn: d.Column,
+				EndLine:     d.EndLine,
+				EndColumn:   d.EndColumn,
+			}
+		}
+	}
+	if d.Path != "" {
+		loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: d.Path}}
+	}
+	return loc, loc.PhysicalLocation != nil || loc.LogicalLocations != nil
+}
+
+// MarshalSARIF serialize diagnostics of ``err`` as a SARIF 2.1.0 log that is reported by a tool named
+// ``toolName``, each error code is reported as a rule.
+func MarshalSARIF(err error, toolName string) ([]byte, error) {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: toolName}}, Results: []sarifResult{}}
+	rules := map[ErrorCode]bool{}
+	for _, d := range NewDiagnostics(err) {
+		if !rules[d.Code] {
+			rules[d.Code] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: string(d.Code)})
+		}
+
+		result := sarifResult{RuleID: string(d.Code), Level: "error", Message: sarifMessage{Text: d.Message}}
+		if loc, ok := d.sarifLocation(); ok {
+			result.Locations = []sarifLocation{loc}
+		}
+		for _, site := range d.IncludedFrom {
+			if loc, ok := site.sarifLocation(); ok {
+				loc.Message = &sarifMessage{Text: "included from here"}
+				result.RelatedLocations = append(result.RelatedLocations, loc)
+			}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+}
@@ -0,0 +1,193 @@
+package yaml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Document is a yaml document that is loaded without resolving its tags, so it could be edited and written
+// back with its original comments, tags and styles.
+type Document struct {
+	Filename string
+	Root     *Node
+	// Indent is number of spaces that is used for indentation when document is written, it is detected
+	// from the original content when the document is loaded
+	Indent int
+}
+
+// LoadDocument parse ``content`` into a ``Document`` without resolving any tag.
+func (loader *Loader) LoadDocument(content []byte, filename string) (*Document, error) {
+	var root Node
+	if err := UnmarshalYaml(content, &root); err != nil {
+		return nil, err
+	}
+	if root.Kind == 0 {
+		// empty content
+		root = Node{Kind: DocumentNode, Content: []*Node{{Kind: MappingNode, Tag: "!!map"}}}
+	}
+	return &Document{Filename: filename, Root: &root, Indent: detectIndent(content)}, nil
+}
+
+// LoadDocumentPath read file at ``path`` from filesystem of the loader and parse it into a ``Document``
+// without resolving any tag.
+func (loader *Loader) LoadDocumentPath(path string) (*Document, error) {
+	if content, err := loader.fs.ReadFile(path); err != nil {
+		return nil, err
+	} else if fullpath, err := loader.fs.Abs(path); err != nil {
+		return nil, err
+	} else {
+		return loader.LoadDocument(content, fullpath)
+	}
+}
+
+// Get return the node at ``path``(for example ``server.ports[0]``), an empty path return the root node.
+func (d *Document) Get(path string) (*Node, error) {
+	return FindNode(d.Root, path)
+}
+
+// Set replace the node at ``path`` with ``value``(either a ``*Node`` or a go value), missing mappings
+// in the path will be created. comments of the replaced node and its style(if the type of value is not
+// changed) are preserved.
+func (d *Document) Set(path string, value interface{}) error {
+	node, err := ValueToNode(value)
+	if err != nil {
+		return err
+	}
+
+	parent, last, err := d.parentOf(path, true)
+	if err != nil {
+		return err
+	}
+
+	if last.IsIndex {
+		if parent.Kind != SequenceNode {
+			return fmt.Errorf("parent of %s is not a sequence: %w", last, Err_BadNodeKind)
+		} else if last.Index > len(parent.Content) {
+			return fmt.Errorf("%s is out of range: %w", last, Err_InvalidPath)
+		} else if last.Index == len(parent.Content) {
+			parent.Content = append(parent.Content, node)
+		} else {
+			parent.Content[last.Index] = replaceNode(parent.Content[last.Index], node)
+		}
+		return nil
+	}
+
+	if existing := GetMappingNode(parent, last.Key); existing != nil {
+		node = replaceNode(existing, node)
+	}
+	return SetMappingNode(parent, last.Key, node)
+}
+
+// Delete remove the node at ``path`` from its parent.
+func (d *Document) Delete(path string) error {
+	parent, last, err := d.parentOf(path, false)
+	if err != nil {
+		return err
+	}
+
+	if last.IsIndex {
+		if parent.Kind != SequenceNode {
+			return fmt.Errorf("parent of %s is not a sequence: %w", last, Err_BadNodeKind)
+		} else if last.Index >= len(parent.Content) {
+			return fmt.Errorf("%s is out of range: %w", last, Err_InvalidPath)
+		}
+		parent.Content = append(parent.Content[:last.Index], parent.Content[last.Index+1:]...)
+		return nil
+	}
+
+	if node, err := PopMappingNode(parent, last.Key); err != nil {
+		return err
+	} else if node == nil {
+		return fmt.Errorf("%s does not exist: %w", last, Err_InvalidPath)
+	}
+	return nil
+}
+
+// Append add ``value`` to the end of the sequence at ``path``.
+func (d *Document) Append(path string, value interface{}) error {
+	if node, err := ValueToNode(value); err != nil {
+		return err
+	} else if seq, err := d.Get(path); err != nil {
+		return err
+	} else if seq.Kind != SequenceNode {
+		return fmt.Errorf("%s is not a sequence: %w", path, Err_BadNodeKind)
+	} else {
+		seq.Content = append(seq.Content, node)
+		return nil
+	}
+}
+
+// Encode write the document to ``w``.
+func (d *Document) Encode(w io.Writer) error {
+	encoder := NewEncoder(w)
+	if d.Indent != 0 {
+		encoder.SetIndent(d.Indent)
+	}
+	if err := encoder.Encode(d.Root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// Marshal return content of the document.
+func (d *Document) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (d *Document) parentOf(path string, create bool) (*Node, NodePathSegment, error) {
+	segments, err := ParseNodePath(path)
+	if err != nil {
+		return nil, NodePathSegment{}, err
+	} else if len(segments) == 0 {
+		return nil, NodePathSegment{}, fmt.Errorf("path must not be empty: %w", Err_InvalidPath)
+	}
+
+	parent := UnwrapNode(d.Root)
+	for _, segment := range segments[:len(segments)-1] {
+		if child, err := FindNodeByPath(parent, []NodePathSegment{segment}); err == nil {
+			parent = UnwrapNode(child)
+		} else if create && !segment.IsIndex && parent.Kind == MappingNode {
+			child = &Node{Kind: MappingNode, Tag: "!!map"}
+			if err = SetMappingNode(parent, segment.Key, child); err != nil {
+				return nil, NodePathSegment{}, err
+			}
+			parent = child
+		} else {
+			return nil, NodePathSegment{}, err
+		}
+	}
+	return parent, segments[len(segments)-1], nil
+}
+
+// replaceNode prepare ``node`` to replace ``existing`` by keeping comments of ``existing`` and its style
+// when type of the value is not changed.
+func replaceNode(existing, node *Node) *Node {
+	if existing.Kind == node.Kind && existing.ShortTag() == node.ShortTag() {
+		node.Style = existing.Style
+	}
+	node.HeadComment = existing.HeadComment
+	node.LineComment = existing.LineComment
+	node.FootComment = existing.FootComment
+	return node
+}
+
+// detectIndent return the smallest indentation that is used in ``content`` or 0 if content has no
+// indentation.
+func detectIndent(content []byte) int {
+	indent := 0
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if n := len(line) - len(trimmed); n != 0 && trimmed != "" && trimmed[0] != '#' && trimmed[0] != '-' {
+			if indent == 0 || n < indent {
+				indent = n
+			}
+		}
+	}
+	return indent
+}
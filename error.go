@@ -1,7 +1,9 @@
 package yaml
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/mehdi-roozitalab/core_utils"
 )
@@ -13,25 +15,87 @@ const (
 	Err_BadNodeKind         = core_utils.ConstError("bad kind of node")
 	Err_MissingRequiredNode = core_utils.ConstError("missing required node")
 	Err_InvalidCase         = core_utils.ConstError("invalid case, case value must be a boolean")
+	Err_IncludeCycle        = core_utils.ConstError("include cycle detected")
+	Err_MaxIncludeDepth     = core_utils.ConstError("maximum include depth exceeded")
+	Err_SandboxViolation    = core_utils.ConstError("sandbox violation")
+	Err_InvalidPath         = core_utils.ConstError("invalid path")
+	Err_UnknownVariable     = core_utils.ConstError("unknown variable")
+	Err_MissingFile         = core_utils.ConstError("missing file")
+	Err_MissingEnvVariable  = core_utils.ConstError("missing environment variable")
+	Err_EmptyPath           = core_utils.ConstError("empty path")
+	Err_MalformedCase       = core_utils.ConstError("malformed case")
+	Err_NoMatchingCase      = core_utils.ConstError("no matching case")
+	Err_Template            = core_utils.ConstError("template failure")
+	Err_InvalidValue        = core_utils.ConstError("invalid value")
+	Err_Decode              = core_utils.ConstError("decode failure")
+	Err_UnknownField        = core_utils.ConstError("unknown field")
+	Err_DuplicateKey        = core_utils.ConstError("duplicate key")
+	Err_SchemaViolation     = core_utils.ConstError("schema violation")
+	Err_Validation          = core_utils.ConstError("validation failure")
 )
 
 type YamlError struct {
 	Location
 	Err error
+
+	// node is the node of the error, the loader use it to complete the location
+	node *Node
 }
 
 func (e *YamlError) Error() string {
 	return fmt.Sprintf("%s: %v", e.Location, e.Err)
 }
+func (e *YamlError) Unwrap() error { return e.Err }
+
+// Code return the machine readable code of the error, see ``GetErrorCode``.
+func (e *YamlError) Code() ErrorCode { return GetErrorCode(e.Err) }
+
+// YamlErrors is a list of errors that are reported together, ``errors.As`` and ``errors.Is`` may be used
+// to find each one of them.
+type YamlErrors []*YamlError
+
+func (e YamlErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+func (e YamlErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+func (e YamlErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// isYamlError report if ``err`` is a ``YamlError`` or ``YamlErrors``, they already have a location
+func isYamlError(err error) bool {
+	switch err.(type) {
+	case *YamlError, YamlErrors:
+		return true
+	}
+	return false
+}
 
 func NewYamlError(node *Node, err error) error {
-	if _, ok := err.(*YamlError); ok {
+	if isYamlError(err) {
 		return err
 	}
 
 	return &YamlError{
-		Location: NodeLocation(node),
+		Location: nodePosition(node),
 		Err:      err,
+		node:     node,
 	}
 }
 func NewYamlConstError(node *Node, err string) error {
@@ -0,0 +1,72 @@
+package yaml
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/mehdi-roozitalab/core_utils"
+)
+
+// DefaultMaxErrors is the maximum number of errors that are collected when ``WithErrorAggregation`` is
+// used with a non positive limit.
+const DefaultMaxErrors = 100
+
+const errErrorLimitReached = core_utils.ConstError("too many errors")
+
+// WithErrorAggregation force the loader to continue after a tag fails to resolve, the failed node will be
+// replaced with null and all errors are returned as a ``YamlErrors`` sorted by their location.
+// loading will stop after ``maxErrors`` errors are collected.
+func WithErrorAggregation(maxErrors int) LoaderOption {
+	return func(loader *Loader) {
+		if maxErrors <= 0 {
+			maxErrors = DefaultMaxErrors
+		}
+		loader.collectErrors = true
+		loader.maxErrors = maxErrors
+	}
+}
+
+// recordError add ``err`` to collected errors of the loader and return ``errErrorLimitReached`` if no
+// more errors should be collected.
+func (loader *Loader) recordError(node *Node, err error) error {
+	loader.errors = append(loader.errors, loader.toYamlErrors(node, err)...)
+	if len(loader.errors) >= loader.maxErrors {
+		loader.errors = loader.errors[:loader.maxErrors]
+		return errErrorLimitReached
+	}
+	return nil
+}
+
+// takeErrors return collected errors sorted by their location and reset them
+func (loader *Loader) takeErrors() YamlErrors {
+	errs := loader.errors
+	loader.errors = nil
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i].Location, errs[j].Location
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		} else if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return errs
+}
+
+func (loader *Loader) toYamlErrors(node *Node, err error) YamlErrors {
+	var sandboxErr *SandboxError
+	switch e := err.(type) {
+	case YamlErrors:
+		return e
+	case *YamlError:
+		return YamlErrors{e}
+	}
+
+	if errors.As(err, &sandboxErr) {
+		return YamlErrors{{Location: sandboxErr.Location, Err: err}}
+	} else if node == nil {
+		return YamlErrors{{Err: err}}
+	}
+	return YamlErrors{{Location: loader.NodeLocation(node), Err: err}}
+}
@@ -0,0 +1,115 @@
+package yaml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// ErrorCode is a stable and machine readable identifier of a kind of error.
+type ErrorCode string
+
+const (
+	Code_Unknown             ErrorCode = "unknown"
+	Code_InvalidObject       ErrorCode = "invalid_object"
+	Code_InvalidChild        ErrorCode = "invalid_child"
+	Code_MissingItems        ErrorCode = "missing_items"
+	Code_BadNodeKind         ErrorCode = "bad_node_kind"
+	Code_MissingRequiredNode ErrorCode = "missing_required_node"
+	Code_InvalidCase         ErrorCode = "invalid_case"
+	Code_IncludeCycle        ErrorCode = "include_cycle"
+	Code_MaxIncludeDepth     ErrorCode = "max_include_depth"
+	Code_SandboxViolation    ErrorCode = "sandbox_violation"
+	Code_InvalidPath         ErrorCode = "invalid_path"
+	Code_UnknownVariable     ErrorCode = "unknown_variable"
+	Code_MissingFile         ErrorCode = "missing_file"
+	Code_MissingEnvVariable  ErrorCode = "missing_env_variable"
+	Code_EmptyPath           ErrorCode = "empty_path"
+	Code_MalformedCase       ErrorCode = "malformed_case"
+	Code_NoMatchingCase      ErrorCode = "no_matching_case"
+	Code_Template            ErrorCode = "template"
+	Code_InvalidValue        ErrorCode = "invalid_value"
+	Code_Decode              ErrorCode = "decode"
+	Code_UnknownField        ErrorCode = "unknown_field"
+	Code_DuplicateKey        ErrorCode = "duplicate_key"
+	Code_SchemaViolation     ErrorCode = "schema_violation"
+	Code_Validation          ErrorCode = "validation"
+	Code_Canceled            ErrorCode = "canceled"
+)
+
+// errorCatalogue map each sentinel error to its code, more specific errors come first
+var errorCatalogue = []struct {
+	Err  error
+	Code ErrorCode
+}{
+	{Err_IncludeCycle, Code_IncludeCycle},
+	{Err_MaxIncludeDepth, Code_MaxIncludeDepth},
+	{Err_SandboxViolation, Code_SandboxViolation},
+	{Err_UnknownVariable, Code_UnknownVariable},
+	{Err_MissingFile, Code_MissingFile},
+	{Err_MissingEnvVariable, Code_MissingEnvVariable},
+	{Err_EmptyPath, Code_EmptyPath},
+	{Err_MalformedCase, Code_MalformedCase},
+	{Err_NoMatchingCase, Code_NoMatchingCase},
+	{Err_InvalidCase, Code_InvalidCase},
+	{Err_Template, Code_Template},
+	{Err_UnknownField, Code_UnknownField},
+	{Err_DuplicateKey, Code_DuplicateKey},
+	{Err_SchemaViolation, Code_SchemaViolation},
+	{Err_Decode, Code_Decode},
+	{Err_InvalidPath, Code_InvalidPath},
+	{Err_InvalidValue, Code_InvalidValue},
+	{Err_InvalidObject, Code_InvalidObject},
+	{Err_InvalidChild, Code_InvalidChild},
+	{Err_MissingItems, Code_MissingItems},
+	{Err_MissingRequiredNode, Code_MissingRequiredNode},
+	{Err_BadNodeKind, Code_BadNodeKind},
+	{Err_Validation, Code_Validation},
+	{fs.ErrNotExist, Code_MissingFile},
+	{context.Canceled, Code_Canceled},
+	{context.DeadlineExceeded, Code_Canceled},
+}
+
+// GetErrorCode return the code of the first sentinel error of the catalogue that ``err`` wraps or
+// ``Code_Unknown`` if it wraps none of them.
+func GetErrorCode(err error) ErrorCode {
+	for _, entry := range errorCatalogue {
+		if errors.Is(err, entry.Err) {
+			return entry.Code
+		}
+	}
+	return Code_Unknown
+}
+
+// messageError is an error with a custom message that wraps a sentinel error without adding its message
+type messageError struct {
+	message string
+	err     error
+}
+
+func (e *messageError) Error() string { return e.message }
+func (e *messageError) Unwrap() error { return e.err }
+
+// causeError is an error of a category(a sentinel error) that is caused by another error, both of them are
+// wrapped so they could be found by ``errors.Is`` and ``errors.As``
+type causeError struct {
+	message string
+	kind    error
+	cause   error
+}
+
+// causeErrorf create an error with the message ``format: kind: cause`` that wraps both ``kind`` and ``cause``
+func causeErrorf(kind, cause error, format string, a ...interface{}) error {
+	message := fmt.Sprintf("%s: %v: %v", fmt.Sprintf(format, a...), kind, cause)
+	return &causeError{message: message, kind: kind, cause: cause}
+}
+
+func (e *causeError) Error() string { return e.message }
+func (e *causeError) Unwrap() error { return e.cause }
+func (e *causeError) Is(target error) bool {
+	return errors.Is(e.kind, target)
+}
+func (e *causeError) As(target interface{}) bool {
+	return errors.As(e.kind, target)
+}
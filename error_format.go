@@ -0,0 +1,155 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+	ansiBlue  = "\x1b[34m"
+)
+
+// ErrorFormatter render errors of the loader for humans, similar to what compilers show: the offending
+// line of the source with a caret under the column, surrounding lines and the include chain that led there.
+type ErrorFormatter struct {
+	// FS is used to read source files, by default it is the disk
+	FS FileSystem
+	// Sources is content of files that can't be read from FS(for example ``<input>``), keyed by filename
+	Sources map[string][]byte
+	// Context is number of lines that are shown before and after the offending line
+	Context int
+	// Color enable ANSI colors
+	Color bool
+
+	files map[string][]string
+}
+
+// FormatError render ``err`` using an ``ErrorFormatter`` with one line of context and no color.
+func FormatError(err error) string {
+	f := ErrorFormatter{Context: 1}
+	return f.Format(err)
+}
+
+// Format render ``err``, each ``YamlError`` in it(``YamlErrors`` are expanded) is rendered with a snippet of
+// its source and other errors are rendered as a simple message.
+func (f *ErrorFormatter) Format(err error) string {
+	var sb strings.Builder
+	for i, e := range flattenErrors(err) {
+		if i != 0 {
+			sb.WriteByte('\n')
+		}
+		if e.Filename == "" && e.Line == 0 {
+			f.writeHeader(&sb, e.Err.Error())
+		} else {
+			f.formatYamlError(&sb, e.Location, e.Err.Error())
+		}
+	}
+	return sb.String()
+}
+
+// flattenErrors convert ``err`` to a list of ``YamlError``, ``YamlErrors`` are expanded and errors without
+// a location are returned with an empty location
+func flattenErrors(err error) []*YamlError {
+	var ye *YamlError
+	var se *SandboxError
+	var errs YamlErrors
+	if errors.As(err, &errs) {
+		return errs
+	} else if errors.As(err, &se) {
+		return []*YamlError{{Location: se.Location, Err: &messageError{message: se.message(), err: se}}}
+	} else if errors.As(err, &ye) {
+		return []*YamlError{ye}
+	} else {
+		return []*YamlError{{Err: err}}
+	}
+}
+
+func (f *ErrorFormatter) color(code, s string) string {
+	if f.Color {
+		return code + s + ansiReset
+	}
+	return s
+}
+
+func (f *ErrorFormatter) writeHeader(sb *strings.Builder, message string) {
+	sb.WriteString(f.color(ansiBold+ansiRed, "error"))
+	sb.WriteString(f.color(ansiBold, ": "+message))
+	sb.WriteByte('\n')
+}
+
+func (f *ErrorFormatter) formatYamlError(sb *strings.Builder, loc Location, message string) {
+	f.writeHeader(sb, message)
+
+	lines := f.sourceLines(loc.Filename)
+	gutter := len(fmt.Sprint(loc.Line + f.Context))
+	pad := strings.Repeat(" ", gutter)
+
+	position := fmt.Sprintf("%s:%d:%d", loc.Filename, loc.Line, loc.Column)
+	if loc.Path != "" {
+		position += " (" + loc.Path + ")"
+	}
+	fmt.Fprintf(sb, "%s%s %s\n", pad, f.color(ansiBlue, "-->"), position)
+
+	if loc.Line > 0 && loc.Line <= len(lines) {
+		fmt.Fprintf(sb, "%s %s\n", pad, f.color(ansiBlue, "|"))
+		first, last := loc.Line-f.Context, loc.Line+f.Context
+		if first < 1 {
+			first = 1
+		}
+		if last > len(lines) {
+			last = len(lines)
+		}
+		for n := first; n <= last; n++ {
+			fmt.Fprintf(sb, "%s %s\n", f.color(ansiBlue, fmt.Sprintf("%*d |", gutter, n)), lines[n-1])
+			if n == loc.Line {
+				column := loc.Column
+				if column < 1 {
+					column = 1
+				}
+				fmt.Fprintf(sb, "%s %s %s%s\n", pad, f.color(ansiBlue, "|"), strings.Repeat(" ", column-1),
+					f.color(ansiBold+ansiRed, "^"))
+			}
+		}
+	}
+
+	chain := loc.IncludeChain()
+	for i := len(chain) - 1; i >= 0; i-- {
+		site := chain[i]
+		fmt.Fprintf(sb, "%s %s included from %s:%d:%d\n", pad, f.color(ansiBlue, "="), site.Filename, site.Line,
+			site.Column)
+	}
+}
+
+func (f *ErrorFormatter) sourceLines(filename string) []string {
+	if lines, ok := f.files[filename]; ok {
+		return lines
+	}
+
+	var lines []string
+	if content, ok := f.Sources[filename]; ok {
+		lines = splitLines(content)
+	} else {
+		fsys := f.FS
+		if fsys == nil {
+			fsys = OSFileSystem()
+		}
+		if content, err := fsys.ReadFile(filename); err == nil {
+			lines = splitLines(content)
+		}
+	}
+
+	if f.files == nil {
+		f.files = map[string][]string{}
+	}
+	f.files[filename] = lines
+	return lines
+}
+
+func splitLines(content []byte) []string {
+	s := strings.ReplaceAll(string(content), "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,101 @@
+package yaml
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mehdi-roozitalab/core_utils"
+)
+
+// FileSystem is the abstraction that ``Loader`` use to access files, by default it access the disk but it
+// may be replaced by any ``fs.FS``(``embed.FS``, ``fstest.MapFS``, ...) using ``NewFileSystem``.
+type FileSystem interface {
+	ReadFile(name string) ([]byte, error)
+	Stat(name string) (fs.FileInfo, error)
+	Glob(pattern string) ([]string, error)
+	// Abs return canonical absolute form of a path, this form is used to identify loaded files
+	Abs(name string) (string, error)
+	IsAbs(name string) bool
+	Dir(name string) string
+	Join(elem ...string) string
+	// EvalSymlinks return the path name after the evaluation of any symbolic links
+	EvalSymlinks(name string) (string, error)
+}
+
+type osFileSystem struct{}
+
+// OSFileSystem return a ``FileSystem`` that access the disk of the machine.
+func OSFileSystem() FileSystem { return osFileSystem{} }
+
+func (osFileSystem) ReadFile(name string) ([]byte, error)  { return os.ReadFile(name) }
+func (osFileSystem) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+func (osFileSystem) Glob(pattern string) ([]string, error) { return filepath.Glob(pattern) }
+func (osFileSystem) Abs(name string) (string, error)       { return core_utils.AbsolutePath(name) }
+func (osFileSystem) IsAbs(name string) bool                { return filepath.IsAbs(name) }
+func (osFileSystem) Dir(name string) string                { return filepath.Dir(name) }
+func (osFileSystem) Join(elem ...string) string            { return filepath.Join(elem...) }
+func (osFileSystem) EvalSymlinks(name string) (string, error) {
+	return filepath.EvalSymlinks(name)
+}
+
+type ioFileSystem struct {
+	fsys fs.FS
+}
+
+// NewFileSystem wrap an ``fs.FS`` as a ``FileSystem``. paths are slash separated and they are relative
+// to the root of ``fsys``, a leading slash is allowed and ``..`` never escape the root.
+func NewFileSystem(fsys fs.FS) FileSystem {
+	if fsys == nil {
+		panic(core_utils.ConstError("missing fs"))
+	}
+	return ioFileSystem{fsys: fsys}
+}
+
+func (f ioFileSystem) ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(f.fsys, f.fsName(name))
+}
+func (f ioFileSystem) Stat(name string) (fs.FileInfo, error) { return fs.Stat(f.fsys, f.fsName(name)) }
+func (f ioFileSystem) Glob(pattern string) ([]string, error) {
+	matches, err := fs.Glob(f.fsys, f.fsName(pattern))
+	if err != nil {
+		return nil, err
+	}
+	for i := range matches {
+		matches[i] = "/" + matches[i]
+	}
+	return matches, nil
+}
+func (f ioFileSystem) Abs(name string) (string, error) { return path.Clean("/" + name), nil }
+func (f ioFileSystem) IsAbs(name string) bool          { return path.IsAbs(name) }
+func (f ioFileSystem) Dir(name string) string          { return path.Dir(name) }
+func (f ioFileSystem) Join(elem ...string) string      { return path.Join(elem...) }
+
+// EvalSymlinks of an ``fs.FS`` is same as ``Abs``, because ``fs.FS`` has no notion of symbolic links
+func (f ioFileSystem) EvalSymlinks(name string) (string, error) { return f.Abs(name) }
+
+// fsName convert a path to the form that is accepted by ``fs.FS``
+func (f ioFileSystem) fsName(name string) string {
+	if name = strings.TrimPrefix(path.Clean("/"+name), "/"); name == "" {
+		return "."
+	}
+	return name
+}
+
+// escapeGlob escape meta characters of a glob pattern, so it only match itself
+func escapeGlob(s string) string {
+	var sb strings.Builder
+	for _, ch := range s {
+		switch ch {
+		case '*', '?', '[':
+			sb.WriteByte('[')
+			sb.WriteRune(ch)
+			sb.WriteByte(']')
+		default:
+			sb.WriteRune(ch)
+		}
+	}
+	return sb.String()
+}
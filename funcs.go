@@ -1,11 +1,8 @@
 package yaml
 
 import (
-	"encoding/json"
 	"fmt"
-	"strings"
 
-	"github.com/google/uuid"
 	"github.com/mehdi-roozitalab/core_utils"
 )
 
@@ -15,81 +12,43 @@ type NodeComments struct {
 	Foot string `json:"foot"`
 }
 
-var loaderId = uuid.NewString()
+// NodeFilename always return an empty string, file of nodes is tracked by the loader that loaded them.
+//
+// Deprecated: use ``Loader.NodeFilename``.
+func NodeFilename(node *Node) string { return "" }
 
-func isCommentsFixed(node *Node) bool { return strings.HasPrefix(node.FootComment, loaderId) }
-func fixNodeComment(node *Node, filename string) {
-	if isCommentsFixed(node) {
-		return
-	}
+// NodeName always return an empty string, path of nodes is tracked by the loader that loaded them.
+//
+// Deprecated: use ``Loader.NodeName``.
+func NodeName(node *Node) string { return "" }
 
-	comments := map[string]string{}
-	if node.LineComment != "" {
-		comments["line"] = node.LineComment
-	}
-	if node.HeadComment != "" {
-		comments["head"] = node.HeadComment
-	}
-	if node.FootComment != "" {
-		comments["foot"] = node.FootComment
-	}
+// NodeFullName only contain the position of ``node``, file and path are tracked by the loader that loaded it.
+//
+// Deprecated: use ``Loader.NodeFullName``.
+func NodeFullName(node *Node) string { return nodeFullName(nodePosition(node)) }
 
-	if len(comments) != 0 {
-		c, _ := json.Marshal(comments)
-		node.HeadComment = string(c)
-	}
-	node.FootComment = loaderId + "-" + filename
-	node.LineComment = ""
-}
+// NodeLocation only contain the position of ``node``, file and path are tracked by the loader that loaded it.
+//
+// Deprecated: use ``Loader.NodeLocation``.
+func NodeLocation(node *Node) Location { return nodePosition(node) }
 
-func nodeFilename(node *Node) string { return node.FootComment[len(loaderId)+1:] }
-func nodeName(node *Node) string     { return node.LineComment }
-func nodeFullName(node *Node) string {
-	return fmt.Sprintf("%s(%d:%d):%s", nodeFilename(node), node.Line, node.Column, nodeName(node))
-}
-func nodeLocation(node *Node) Location {
-	return Location{nodeFilename(node), node.Line, node.Column, nodeName(node)}
+// nodePosition is location of a node that is not tracked by a loader, it only know what is recorded in the node
+func nodePosition(node *Node) Location {
+	return Location{Line: node.Line, Column: node.Column}
 }
 
-func NodeFilename(node *Node) string {
-	if isCommentsFixed(node) {
-		return nodeFilename(node)
-	}
-	return ""
-}
-func NodeName(node *Node) string {
-	if isCommentsFixed(node) {
-		return nodeName(node)
-	}
-	return ""
-}
-func NodeFullName(node *Node) string {
-	if isCommentsFixed(node) {
-		return nodeFullName(node)
+func nodeFullName(loc Location) string {
+	if loc.Filename == "" && loc.Path == "" {
+		return fmt.Sprintf("(%d:%d)", loc.Line, loc.Column)
 	}
-	return fmt.Sprintf("(%d:%d)", node.Line, node.Column)
-}
-func NodeLocation(node *Node) Location {
-	if isCommentsFixed(node) {
-		return nodeLocation(node)
-	}
-	return Location{Line: node.Line, Column: node.Column}
+	return fmt.Sprintf("%s(%d:%d):%s", loc.Filename, loc.Line, loc.Column, loc.Path)
 }
 
 func GetNodeComments(node *Node) NodeComments {
-	if isCommentsFixed(node) {
-		var nodeComments NodeComments
-		if node.HeadComment == "" {
-			return NodeComments{}
-		}
-		_ = json.Unmarshal([]byte(node.HeadComment), &nodeComments)
-		return nodeComments
-	} else {
-		return NodeComments{
-			Head: node.HeadComment,
-			Line: node.LineComment,
-			Foot: node.FootComment,
-		}
+	return NodeComments{
+		Head: node.HeadComment,
+		Line: node.LineComment,
+		Foot: node.FootComment,
 	}
 }
 
@@ -136,9 +95,9 @@ func ProcessMappingNode(node *Node, processor func(index int, name string, node
 // return ``Err_BadKind`` if node is not a ``MappingNode``.
 func PopMappingNode(node *Node, key string) (*Node, error) {
 	var res *Node
-	err := ProcessMappingNode2(node, func(index int, name string, node *Node) (bool, error) {
+	err := ProcessMappingNode2(node, func(index int, name string, value *Node) (bool, error) {
 		if name == key {
-			res = node
+			res = value
 			node.Content = append(node.Content[:index], node.Content[index+2:]...)
 			return false, nil
 		}
@@ -149,6 +108,37 @@ func PopMappingNode(node *Node, key string) (*Node, error) {
 	return res, err
 }
 
+// GetMappingNode return value of a key of a ``MappingNode`` or nil if node is not a mapping or it has
+// no such key.
+func GetMappingNode(node *Node, key string) *Node {
+	if node.Kind != MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// SetMappingNode set value of a key of a ``MappingNode``, the key will be added to the end of the mapping
+// if it does not exist.
+// return ``Err_BadKind`` if node is not a ``MappingNode``.
+func SetMappingNode(node *Node, key string, value *Node) error {
+	if node.Kind != MappingNode {
+		return NewYamlError(node, Err_BadNodeKind)
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return nil
+		}
+	}
+	node.Content = append(node.Content, &Node{Kind: ScalarNode, Tag: "!!str", Value: key}, value)
+	return nil
+}
+
 // PopMappingNodeTo pop a node with specified key from a ``MappingNode`` and decode it into ``target``.
 // return ``Err_BadKind`` if node is not a ``MappingNode``.
 // return ``Err_MissingRequiredNode`` if no node exists with specified ``key``.
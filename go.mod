@@ -3,7 +3,6 @@ module github.com/mehdi-roozitalab/yaml
 go 1.16
 
 require (
-	github.com/google/uuid v1.3.0
 	github.com/mehdi-roozitalab/core_utils v0.9.1
 	github.com/mehdi-roozitalab/template v0.9.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -1,9 +1,18 @@
 package yaml
 
-import y "gopkg.in/yaml.v3"
+import (
+	"io"
+
+	y "gopkg.in/yaml.v3"
+)
 
 type Node = y.Node
 type Kind = y.Kind
+type Style = y.Style
+type Encoder = y.Encoder
+type Decoder = y.Decoder
+type TypeError = y.TypeError
+type Unmarshaler = y.Unmarshaler
 
 const (
 	DocumentNode = y.DocumentNode
@@ -13,5 +22,16 @@ const (
 	AliasNode    = y.AliasNode
 )
 
+const (
+	TaggedStyle       = y.TaggedStyle
+	DoubleQuotedStyle = y.DoubleQuotedStyle
+	SingleQuotedStyle = y.SingleQuotedStyle
+	LiteralStyle      = y.LiteralStyle
+	FoldedStyle       = y.FoldedStyle
+	FlowStyle         = y.FlowStyle
+)
+
 func UnmarshalYaml(data []byte, target interface{}) error { return y.Unmarshal(data, target) }
 func MarshalYaml(data interface{}) ([]byte, error)        { return y.Marshal(data) }
+func NewEncoder(w io.Writer) *Encoder                     { return y.NewEncoder(w) }
+func NewYamlDecoder(r io.Reader) *Decoder                 { return y.NewDecoder(r) }
@@ -0,0 +1,91 @@
+package yaml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultMaxIncludeDepth is the maximum depth of nested includes of a ``Loader`` unless it is changed
+// using ``WithMaxIncludeDepth``.
+const DefaultMaxIncludeDepth = 64
+
+// WithMaxIncludeDepth change maximum depth of nested includes, 0 means no limit.
+func WithMaxIncludeDepth(depth int) LoaderOption {
+	return func(loader *Loader) { loader.maxIncludeDepth = depth }
+}
+
+type includeFrame struct {
+	Filename string
+	// Site is the node that included this file, it is nil for the file that loading is started from
+	Site *Node
+}
+
+func (loader *Loader) pushInclude(filename string, site *Node) {
+	loader.includes = append(loader.includes, includeFrame{Filename: filename, Site: site})
+}
+func (loader *Loader) popInclude() {
+	loader.includes = loader.includes[:len(loader.includes)-1]
+}
+
+// IncludeDepth return number of files that are currently being loaded by the loader.
+func (loader *Loader) IncludeDepth() int { return len(loader.includes) }
+
+// includeChain render the chain of includes that leads to including ``filename`` from ``node``.
+// for example ``a.yaml:3 -> b.yaml:7 -> a.yaml``
+func (loader *Loader) includeChain(node *Node, filename string) string {
+	parts := make([]string, 0, len(loader.includes)+1)
+	for i, frame := range loader.includes {
+		site := node
+		if i+1 < len(loader.includes) {
+			site = loader.includes[i+1].Site
+		}
+		parts = append(parts, fmt.Sprintf("%s:%d", frame.Filename, site.Line))
+	}
+	parts = append(parts, filename)
+	return strings.Join(parts, " -> ")
+}
+
+func (loader *Loader) checkInclude(node *Node, filename string) error {
+	for _, frame := range loader.includes {
+		if frame.Filename == filename {
+			return NewYamlErrorf(node, "%w: %s", Err_IncludeCycle, loader.includeChain(node, filename))
+		}
+	}
+	if loader.maxIncludeDepth > 0 && len(loader.includes) >= loader.maxIncludeDepth {
+		return NewYamlErrorf(node, "%w(%d): %s", Err_MaxIncludeDepth, loader.maxIncludeDepth,
+			loader.includeChain(node, filename))
+	}
+	return nil
+}
+
+// IncludePath load the file at ``path`` into ``target`` as a file that is included by ``node``.
+// unlike ``LoadPath`` it will check the file against files that are currently being loaded and reject
+// include cycles and includes that are deeper than the maximum include depth of the loader.
+func (loader *Loader) IncludePath(node *Node, path string, target interface{}) error {
+	content, fullpath, err := loader.readInclude(node, path)
+	if err != nil {
+		return err
+	}
+	return loader.load(content, target, fullpath, node)
+}
+
+// readInclude check and read a file that is included by ``node``, it return content of the file and its
+// canonical path.
+func (loader *Loader) readInclude(node *Node, path string) ([]byte, string, error) {
+	if err := loader.CheckPath(node, path); err != nil {
+		return nil, "", err
+	}
+
+	fullpath, err := loader.fs.Abs(path)
+	if err != nil {
+		return nil, "", err
+	} else if err = loader.checkInclude(node, fullpath); err != nil {
+		return nil, "", err
+	}
+
+	content, err := loader.fs.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, fullpath, nil
+}
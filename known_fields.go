@@ -0,0 +1,108 @@
+package yaml
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// decode decode ``node`` into ``target``, if the loader only accept known fields, mappings that have a key
+// without a matching field in their struct are rejected before anything is decoded. in strict mode
+// mappings with duplicate keys are rejected too and if the loader has a schema the document is validated
+// against it. fields that are tagged with ``required:"true"`` must exist and fields that are missing are
+// set to the value of their ``default`` tag. at the end values that implement ``Validator`` are validated.
+func (loader *Loader) decode(node *Node, target interface{}) error {
+	var errs YamlErrors
+	// included files are checked as part of the document that include them, after they are spliced
+	if loader.strict && loader.IncludeDepth() == 1 {
+		errs = loader.findDuplicateKeys(node)
+	}
+	if loader.schema != nil && loader.IncludeDepth() == 1 {
+		errs = append(errs, validateSchema(node, loader.schema, loader.NodeLocation)...)
+	}
+	if loader.knownFields || loader.strict {
+		errs = append(errs, loader.findUnknownFields(node, reflect.TypeOf(target))...)
+	}
+	errs = append(errs, loader.findMissingRequired(node, reflect.TypeOf(target))...)
+	if len(errs) != 0 {
+		return errs
+	}
+
+	if err := loader.decodeNode(node, target); err != nil {
+		return err
+	} else if target, ok := target.(*Node); ok {
+		// a node target receive a copy of the root node
+		loader.provenance.Copy(node, target)
+	}
+
+	if err := loader.applyDefaults(reflect.ValueOf(target), node, node); err != nil {
+		return err
+	} else if errs = loader.callValidators(reflect.ValueOf(target), node, node); len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// findUnknownFields walk ``node`` and ``typ`` together and report every key of a mapping that is decoded
+// into a struct and has no matching field in it, like the ``KnownFields`` option of the yaml decoder.
+func (loader *Loader) findUnknownFields(node *Node, typ reflect.Type) YamlErrors {
+	if typ == nil {
+		return nil
+	}
+
+	base := indirectType(typ)
+	if reflect.PtrTo(base).Implements(unmarshalerType) {
+		return nil
+	}
+
+	var errs YamlErrors
+	value := UnwrapNode(node)
+	switch {
+	case value.Kind == MappingNode && base.Kind() == reflect.Struct:
+		errs = loader.findUnknownStructFields(value, base)
+
+	case value.Kind == MappingNode && base.Kind() == reflect.Map:
+		for i := 0; i < len(value.Content); i += 2 {
+			errs = append(errs, loader.findUnknownFields(value.Content[i+1], base.Elem())...)
+		}
+
+	case value.Kind == SequenceNode && (base.Kind() == reflect.Slice || base.Kind() == reflect.Array):
+		for _, item := range value.Content {
+			errs = append(errs, loader.findUnknownFields(item, base.Elem())...)
+		}
+	}
+	return errs
+}
+
+func (loader *Loader) findUnknownStructFields(node *Node, typ reflect.Type) YamlErrors {
+	info, err := getStructInfo(typ)
+	if err != nil {
+		return nil
+	}
+
+	var errs YamlErrors
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			if merged := UnwrapNode(value); merged.Kind == SequenceNode {
+				for _, item := range merged.Content {
+					errs = append(errs, loader.findUnknownFields(item, typ)...)
+				}
+			} else {
+				errs = append(errs, loader.findUnknownFields(merged, typ)...)
+			}
+		} else if field := info.FieldByKey(key.Value); field != nil {
+			errs = append(errs, loader.findUnknownFields(value, field.Field.Type)...)
+		} else if info.InlineMap != nil {
+			errs = append(errs, loader.findUnknownFields(value, indirectType(typ.FieldByIndex(info.InlineMap).Type).Elem())...)
+		} else {
+			// keys are not part of the path, so the error is reported at the key with the path of its value
+			loc := loader.NodeLocation(key)
+			loc.Path = loader.NodeLocation(value).Path
+			errs = append(errs, &YamlError{
+				Location: loc,
+				Err:      fmt.Errorf("field %s not found in type %s: %w", key.Value, typ, Err_UnknownField),
+			})
+		}
+	}
+	return errs
+}
@@ -1,35 +1,99 @@
 package yaml
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"os"
-
-	"github.com/mehdi-roozitalab/core_utils"
 )
 
 type Loader struct {
-	registry  TagRegistry
-	Variables map[string]interface{}
+	registry        TagRegistry
+	fs              FileSystem
+	includes        []includeFrame
+	maxIncludeDepth int
+	roots           []string
+	lookupEnv       func(key string) (string, bool)
+	scopes          []map[string]interface{}
+	provenance      *provenanceTable
+	collectErrors   bool
+	maxErrors       int
+	errors          YamlErrors
+	resetVariables  bool
+	knownFields     bool
+	strict          bool
+	schema          *Schema
+	ctx             context.Context
+	parents         []*Node
+	Variables       map[string]interface{}
+}
+
+// LoaderOption is an optional configuration of a ``Loader`` that may be passed to ``NewLoader``.
+type LoaderOption func(loader *Loader)
+
+// WithFileSystem force the loader to read all files(loaded paths, includes, files and globs) from ``fsys``.
+func WithFileSystem(fsys FileSystem) LoaderOption {
+	return func(loader *Loader) { loader.fs = fsys }
+}
+
+// WithFS force the loader to read all files from an ``fs.FS``, see ``NewFileSystem``.
+func WithFS(fsys fs.FS) LoaderOption { return WithFileSystem(NewFileSystem(fsys)) }
+
+// WithEnvironment replace the source of environment variables of the loader, by default it is ``os.LookupEnv``.
+func WithEnvironment(lookup func(key string) (string, bool)) LoaderOption {
+	return func(loader *Loader) { loader.lookupEnv = lookup }
 }
 
-func NewLoader(registry TagRegistry) *Loader {
-	return &Loader{
-		registry:  registry,
-		Variables: map[string]interface{}{},
+// NewLoader create a loader that resolve tags of ``registry``. a loader keep the state of the load that it
+// is running(include stack, variable scopes, collected errors and locations of nodes), so it must not be
+// used by multiple goroutines at the same time, concurrent loads need a loader each.
+func NewLoader(registry TagRegistry, options ...LoaderOption) *Loader {
+	loader := &Loader{
+		registry:        registry,
+		fs:              OSFileSystem(),
+		maxIncludeDepth: DefaultMaxIncludeDepth,
+		lookupEnv:       os.LookupEnv,
+		provenance:      newProvenanceTable(),
+		Variables:       map[string]interface{}{},
 	}
+	for _, option := range options {
+		option(loader)
+	}
+	return loader
 }
 
 func (loader *Loader) GetTagRegistry() TagRegistry { return loader.registry }
+func (loader *Loader) GetFileSystem() FileSystem   { return loader.fs }
+func (loader *Loader) LookupEnv(key string) (string, bool) {
+	return loader.lookupEnv(key)
+}
 func (loader *Loader) ResolveTags(node *Node) (*Node, error) {
+	if err := loader.checkCanceled(node); err != nil {
+		return nil, err
+	}
+
 	if tag := loader.registry.GetTagByName(node.Tag); tag != nil {
-		if resolved, err := tag.Resolve(loader, node); err != nil {
-			return nil, err
+		resolved, err := loader.resolveTag(tag, node)
+		if err != nil {
+			loader.locateErrors(err)
+			if !loader.collectErrors || errors.Is(err, errErrorLimitReached) || isCanceled(err) {
+				return nil, err
+			} else if err = loader.recordError(node, err); err != nil {
+				return nil, err
+			}
+			return loader.CreateNodeFromTemplate(node, ScalarNode, "!!null", "", nil), nil
 		} else if resolved == nil {
 			return nil, nil
-		} else if err = loader.resolveChildTags(resolved); err != nil {
+		}
+
+		if _, ok := loader.provenance.Get(resolved); !ok {
+			// a node that is created by the tag replace the node of the tag
+			loader.provenance.Copy(node, resolved)
+		}
+		if err = loader.resolveChildTags(resolved); err != nil {
 			return nil, err
-		} else {
-			return resolved, nil
 		}
+		return resolved, nil
 	} else if err := loader.resolveChildTags(node); err != nil {
 		return nil, err
 	}
@@ -37,35 +101,90 @@ func (loader *Loader) ResolveTags(node *Node) (*Node, error) {
 }
 
 func (loader *Loader) Load(content []byte, target interface{}, filename string) error {
-	cl := contentLoader{
+	return loader.load(content, target, filename, nil)
+}
+func (loader *Loader) load(content []byte, target interface{}, filename string, site *Node) error {
+	return loader.loadRoot(func() error { return loader.loadContent(content, target, filename, site) })
+}
+
+// loadRoot run ``load``, if it is the outermost load of the loader locations of the previous load are
+// dropped and errors that are collected by it are reported at its end.
+func (loader *Loader) loadRoot(load func() error) error {
+	if len(loader.includes) != 0 {
+		return load()
+	}
+
+	loader.provenance = newProvenanceTable()
+	loader.errors = nil
+	return loader.collectedErrors(load())
+}
+func (loader *Loader) loadContent(content []byte, target interface{}, filename string, site *Node) error {
+	loader.pushInclude(filename, site)
+	defer loader.popInclude()
+
+	loader.PushScope(nil)
+	defer loader.PopScope()
+
+	return UnmarshalYaml(content, loader.newContentLoader(filename, newSourceText(content), target))
+}
+func (loader *Loader) newContentLoader(filename string, source sourceText, target interface{}) *contentLoader {
+	return &contentLoader{
 		filename: filename,
+		source:   source,
 		loader:   loader,
+		includes: loader.includeSites(),
 		target:   target,
 	}
-	return UnmarshalYaml(content, &cl)
+}
+
+// collectedErrors merge ``err`` with errors that are collected during load
+func (loader *Loader) collectedErrors(err error) error {
+	if len(loader.errors) == 0 {
+		return err
+	} else if err != nil && !errors.Is(err, errErrorLimitReached) {
+		loader.errors = append(loader.errors, loader.toYamlErrors(nil, err)...)
+	}
+	return loader.takeErrors()
 }
 func (loader *Loader) LoadPath(path string, target interface{}) error {
-	if content, err := os.ReadFile(path); err != nil {
+	if content, err := loader.fs.ReadFile(path); err != nil {
+		return err
+	} else if fullpath, err := loader.fs.Abs(path); err != nil {
 		return err
 	} else {
-		wd, err := os.Getwd()
-		if err != nil {
-			return err
-		}
-
-		defer os.Chdir(wd)
+		return loader.Load(content, target, fullpath)
+	}
+}
 
-		fullpath, err := core_utils.AbsolutePath(path)
-		if err != nil {
-			return err
-		}
+// ResolvePath resolve a path that is written in ``node`` against directory of the file that ``node`` is
+// loaded from, absolute paths and paths of nodes without a file are returned unchanged.
+func (loader *Loader) ResolvePath(node *Node, path string) string {
+	if filename := loader.NodeFilename(node); filename == "" || loader.fs.IsAbs(path) {
+		return path
+	} else {
+		return loader.fs.Join(loader.fs.Dir(filename), path)
+	}
+}
 
-		err = loader.Load(content, target, fullpath)
-		return err
+// ResolveGlob is same as ``ResolvePath`` but it will escape directory of the file, so ``pattern`` will
+// only match files in that directory.
+func (loader *Loader) ResolveGlob(node *Node, pattern string) string {
+	if filename := loader.NodeFilename(node); filename == "" || loader.fs.IsAbs(pattern) {
+		return pattern
+	} else {
+		return loader.fs.Join(escapeGlob(loader.fs.Dir(filename)), pattern)
 	}
 }
 
 func (loader *Loader) resolveChildTags(node *Node) error {
+	if node.Kind == MappingNode || node.Kind == SequenceNode {
+		loader.PushScope(nil)
+		defer loader.PopScope()
+
+		loader.parents = append(loader.parents, node)
+		defer func() { loader.parents = loader.parents[:len(loader.parents)-1] }()
+	}
+
 	if node.Kind == MappingNode {
 		for i := 0; i < len(node.Content); i += 2 {
 			if ch, err := loader.ResolveTags(node.Content[i+1]); err != nil {
@@ -100,3 +219,7 @@ func UnmarshalPath(path string, target interface{}) error {
 	loader := NewLoader(NewChildRegistry(DefaultTagRegistry(), NewSimpleTagRegistry()))
 	return loader.LoadPath(path, target)
 }
+func UnmarshalFS(fsys fs.FS, path string, target interface{}) error {
+	loader := NewLoader(NewChildRegistry(DefaultTagRegistry(), NewSimpleTagRegistry()), WithFS(fsys))
+	return loader.LoadPath(path, target)
+}
@@ -0,0 +1,61 @@
+package yaml
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+// testLoad load ``content`` as main.yaml of ``fsys`` with the default registry and return the decoded value
+func testLoad(fsys fstest.MapFS, content string, options ...LoaderOption) (interface{}, error) {
+	if fsys == nil {
+		fsys = fstest.MapFS{}
+	}
+	fsys["main.yaml"] = &fstest.MapFile{Data: []byte(content)}
+
+	var value interface{}
+	loader := NewLoader(DefaultTagRegistry(), append([]LoaderOption{WithFS(fsys)}, options...)...)
+	err := loader.LoadPath("main.yaml", &value)
+	return value, err
+}
+
+func TestLoaderNodeLocation(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.yaml":  {Data: []byte("a:\n  b: !include sub/x.yaml\n")},
+		"sub/x.yaml": {Data: []byte("k: [1, 2]\n")},
+	}
+	loader := NewLoader(DefaultTagRegistry(), WithFS(fsys))
+
+	var root Node
+	if err := loader.LoadPath("main.yaml", &root); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path     string
+		filename string
+		line     int
+		column   int
+		chain    int
+	}{
+		{"a", "/main.yaml", 2, 3, 0},
+		{"a.b", "/sub/x.yaml", 1, 1, 1},
+		{"a.b.k", "/sub/x.yaml", 1, 4, 1},
+		{"a.b.k[1]", "/sub/x.yaml", 1, 8, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			node, err := FindNode(&root, tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			loc := loader.NodeLocation(node)
+			if loc.Filename != tt.filename || loc.Line != tt.line || loc.Column != tt.column {
+				t.Errorf("location is %s(%d:%d), want %s(%d:%d)", loc.Filename, loc.Line, loc.Column, tt.filename,
+					tt.line, tt.column)
+			}
+			if len(loc.IncludeChain()) != tt.chain {
+				t.Errorf("include chain is %v, want %d site(s)", loc.IncludeChain(), tt.chain)
+			}
+		})
+	}
+}
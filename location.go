@@ -1,17 +1,184 @@
 package yaml
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type Location struct {
 	Filename string
 	Line     int
 	Column   int
-	Path     string
+	// EndLine and EndColumn is the position right after the last character of the node, yaml only record
+	// start of nodes so they are found in the source of the node. they are 0 if the end is unknown(for
+	// block scalars, nodes that are created by tags and documents that are read by a ``StreamDecoder``)
+	EndLine   int
+	EndColumn int
+	Path      string
+
+	// includes is shared by all locations of a file, a pointer keep ``Location`` comparable
+	includes *[]Location
+}
+
+// IncludeChain return location of include nodes that lead to the file of this location, outermost first.
+func (loc Location) IncludeChain() []Location {
+	if loc.includes == nil {
+		return nil
+	}
+	return *loc.includes
 }
 
 func (loc Location) String() string {
 	if loc.Path != "" {
 		return fmt.Sprintf("%s@%s(%d:%d)", loc.Path, loc.Filename, loc.Line, loc.Column)
 	}
-	return fmt.Sprintf("(%d:%d)", loc.Line, loc.Column)
+	// errors of the root node have no path but their file is still known
+	return fmt.Sprintf("%s(%d:%d)", loc.Filename, loc.Line, loc.Column)
+}
+
+// sourceText is content of a loaded file as lines of characters, it is used to find end of its nodes
+type sourceText [][]rune
+
+func newSourceText(content []byte) sourceText {
+	if content == nil {
+		return nil
+	}
+	lines := splitLines(content)
+	text := make(sourceText, len(lines))
+	for i, line := range lines {
+		text[i] = []rune(line)
+	}
+	return text
+}
+
+// at return the character at ``index``(0 based) of ``line``(1 based)
+func (s sourceText) at(line, index int) (rune, bool) {
+	if line < 1 || line > len(s) || index < 0 || index >= len(s[line-1]) {
+		return 0, false
+	}
+	return s[line-1][index], true
+}
+
+// skipBlank return position of the first character at or after ``index`` of ``line`` that is not a space,
+// a line break, a comment or a comma(that separate items of flow collections)
+func (s sourceText) skipBlank(line, index int) (int, int) {
+	for line >= 1 && line <= len(s) {
+		if index >= len(s[line-1]) {
+			line, index = line+1, 0
+			continue
+		}
+		switch s[line-1][index] {
+		case ' ', '\t', ',':
+			index++
+		case '#':
+			line, index = line+1, 0
+		default:
+			return line, index
+		}
+	}
+	return line, index
+}
+
+// skipProperties return position of the content of a node that start at ``index`` of ``line``, its tag and
+// anchor are skipped
+func (s sourceText) skipProperties(line, index int) (int, int) {
+	for {
+		if r, ok := s.at(line, index); !ok || (r != '!' && r != '&') {
+			return line, index
+		}
+		for r, ok := s.at(line, index); ok && !strings.ContainsRune(" \t,]}", r); r, ok = s.at(line, index) {
+			index++
+		}
+		line, index = s.skipBlank(line, index)
+	}
+}
+
+// quoteEnd find the position right after the closing quote of a quoted scalar that start at ``index`` of ``line``
+func (s sourceText) quoteEnd(line, index int, quote rune) (int, int) {
+	for index++; line <= len(s); line, index = line+1, 0 {
+		for ; index < len(s[line-1]); index++ {
+			switch r := s[line-1][index]; {
+			case r == '\\' && quote == '"':
+				index++
+			case r == '\'' && quote == '\'' && index+1 < len(s[line-1]) && s[line-1][index+1] == '\'':
+				index++
+			case r == quote:
+				return line, index + 2
+			}
+		}
+	}
+	return 0, 0
+}
+
+// NodeEnd find the position right after the last character of ``node``, 0, 0 is returned if it is unknown
+func (s sourceText) NodeEnd(node *Node) (int, int) {
+	if s == nil || node.Line == 0 {
+		return 0, 0
+	}
+
+	switch node.Kind {
+	case DocumentNode:
+		if len(node.Content) == 0 {
+			return 0, 0
+		}
+		return s.NodeEnd(node.Content[len(node.Content)-1])
+
+	case MappingNode, SequenceNode:
+		if node.Style&FlowStyle == 0 {
+			if len(node.Content) == 0 {
+				return 0, 0
+			}
+			return s.NodeEnd(node.Content[len(node.Content)-1])
+		}
+
+		// end of a flow collection is its closing bracket
+		line, index := s.skipProperties(node.Line, node.Column-1)
+		if r, ok := s.at(line, index); !ok || (r != '[' && r != '{') {
+			return 0, 0
+		}
+		index++
+		if len(node.Content) != 0 {
+			var column int
+			if line, column = s.NodeEnd(node.Content[len(node.Content)-1]); line == 0 {
+				return 0, 0
+			}
+			index = column - 1
+		}
+		line, index = s.skipBlank(line, index)
+		if r, ok := s.at(line, index); ok && (r == ']' || r == '}') {
+			return line, index + 2
+		}
+		return 0, 0
+
+	case AliasNode:
+		if r, ok := s.at(node.Line, node.Column-1); ok && r == '*' {
+			return node.Line, node.Column + 1 + len([]rune(node.Value))
+		}
+		return 0, 0
+
+	default:
+		if node.Style&(LiteralStyle|FoldedStyle) != 0 {
+			// content of block scalars is only limited by indentation of the next lines
+			return 0, 0
+		}
+
+		line, index := s.skipProperties(node.Line, node.Column-1)
+		r, ok := s.at(line, index)
+		if !ok {
+			return 0, 0
+		} else if node.Style&SingleQuotedStyle != 0 && r == '\'' {
+			return s.quoteEnd(line, index, r)
+		} else if node.Style&DoubleQuotedStyle != 0 && r == '"' {
+			return s.quoteEnd(line, index, r)
+		}
+
+		// a plain scalar is written as its value unless it span multiple lines
+		value := []rune(node.Value)
+		if len(value) == 0 || index+len(value) > len(s[line-1]) {
+			return 0, 0
+		} else if string(s[line-1][index:index+len(value)]) != node.Value {
+			return 0, 0
+		}
+		return line, index + len(value) + 1
+	}
 }
@@ -0,0 +1,74 @@
+package yaml
+
+import "testing"
+
+func TestSourceTextNodeEnd(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		path    string
+		line    int
+		column  int
+	}{
+		{"plain scalar", "a: hello\n", "a", 1, 9},
+		{"tagged scalar", "a: !env HOME\n", "a", 1, 13},
+		{"anchored scalar", "a: &x value\n", "a", 1, 12},
+		{"single quoted", "a: 'it''s'\n", "a", 1, 11},
+		{"double quoted", "a: \"x\\\"y\"\n", "a", 1, 10},
+		{"multi-line quoted", "a: \"x\n  y\"\n", "a", 2, 5},
+		{"flow sequence", "a: [1, 2]\n", "a", 1, 10},
+		{"empty flow sequence", "a: []\n", "a", 1, 6},
+		{"multi-line flow mapping", "a: {b: 1,\n  c: 2}\n", "a", 2, 8},
+		{"flow item", "a: [x, yz]\n", "a[1]", 1, 10},
+		{"block mapping", "a:\n  b: 1\n  c: xyz\n", "a", 3, 9},
+		{"block sequence", "a:\n  - 1\n  - 22\n", "a", 3, 7},
+		{"document", "a: 1\nb: 22\n", "", 2, 6},
+		{"unicode", "a: héllo\n", "a", 1, 9},
+		{"block scalar", "a: |\n  text\n", "a", 0, 0},
+		{"multi-line plain scalar", "a: foo\n  bar\n", "a", 0, 0},
+		{"empty block mapping", "a: {}\nb: 1\n", "a", 1, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc Node
+			if err := UnmarshalYaml([]byte(tt.content), &doc); err != nil {
+				t.Fatal(err)
+			}
+			node := &doc
+			if tt.path != "" {
+				var err error
+				if node, err = FindNode(&doc, tt.path); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			line, column := newSourceText([]byte(tt.content)).NodeEnd(node)
+			if line != tt.line || column != tt.column {
+				t.Errorf("end is (%d:%d), want (%d:%d)", line, column, tt.line, tt.column)
+			}
+		})
+	}
+}
+
+func TestSourceTextNodeEndWithoutSource(t *testing.T) {
+	node := &Node{Kind: ScalarNode, Value: "x", Line: 1, Column: 1}
+	if line, column := sourceText(nil).NodeEnd(node); line != 0 || column != 0 {
+		t.Errorf("end is (%d:%d), want (0:0)", line, column)
+	}
+}
+
+func TestLocationString(t *testing.T) {
+	tests := []struct {
+		loc  Location
+		want string
+	}{
+		{Location{Line: 1, Column: 2}, "(1:2)"},
+		{Location{Filename: "a.yaml", Line: 1, Column: 2}, "a.yaml(1:2)"},
+		{Location{Filename: "a.yaml", Line: 1, Column: 2, Path: ".x"}, ".x@a.yaml(1:2)"},
+	}
+	for _, tt := range tests {
+		if got := tt.loc.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
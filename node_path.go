@@ -0,0 +1,139 @@
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NodePathSegment is a single step of a node path, either a key of a mapping or an index of a sequence.
+type NodePathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+func (s NodePathSegment) String() string {
+	if s.IsIndex {
+		return fmt.Sprintf("[%d]", s.Index)
+	}
+	return "." + s.Key
+}
+
+// ParseNodePath parse a dotted/indexed path like ``name.items[0].key`` into its segments.
+func ParseNodePath(path string) ([]NodePathSegment, error) {
+	var segments []NodePathSegment
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("missing ] in path(%s): %w", path, Err_InvalidPath)
+			}
+			index, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index(%s) in path(%s): %w", path[i+1:i+end], path, Err_InvalidPath)
+			}
+			segments = append(segments, NodePathSegment{Index: index, IsIndex: true})
+			i += end + 1
+			continue
+		}
+
+		end := strings.IndexAny(path[i:], ".[")
+		if end == -1 {
+			end = len(path) - i
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("empty key in path(%s): %w", path, Err_InvalidPath)
+		}
+		segments = append(segments, NodePathSegment{Key: path[i : i+end]})
+		i += end
+	}
+	return segments, nil
+}
+
+// FindNodeByPath navigate from ``node`` through mappings and sequences using ``segments`` and return the
+// node at the end of the path, return ``Err_InvalidPath`` if path does not exist in the node.
+func FindNodeByPath(node *Node, segments []NodePathSegment) (*Node, error) {
+	for _, segment := range segments {
+		node = UnwrapNode(node)
+		if segment.IsIndex {
+			if node.Kind != SequenceNode {
+				return nil, fmt.Errorf("parent of %s is not a sequence: %w", segment, Err_BadNodeKind)
+			} else if segment.Index >= len(node.Content) {
+				return nil, fmt.Errorf("%s is out of range: %w", segment, Err_InvalidPath)
+			}
+			node = node.Content[segment.Index]
+		} else {
+			if node.Kind != MappingNode {
+				return nil, fmt.Errorf("parent of %s is not a mapping: %w", segment, Err_BadNodeKind)
+			}
+
+			var child *Node
+			for i := 0; i < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment.Key {
+					child = node.Content[i+1]
+				}
+			}
+			if child == nil {
+				return nil, fmt.Errorf("%s does not exist: %w", segment, Err_InvalidPath)
+			}
+			node = child
+		}
+	}
+	return node, nil
+}
+
+// FindNode is same as ``FindNodeByPath`` but it accept the path as a string.
+func FindNode(node *Node, path string) (*Node, error) {
+	if segments, err := ParseNodePath(path); err != nil {
+		return nil, err
+	} else {
+		return FindNodeByPath(node, segments)
+	}
+}
+
+// UnwrapNode return content of a ``DocumentNode`` or target of an ``AliasNode``, other nodes are
+// returned unchanged.
+func UnwrapNode(node *Node) *Node {
+	for {
+		if node.Kind == DocumentNode && len(node.Content) != 0 {
+			node = node.Content[0]
+		} else if node.Kind == AliasNode && node.Alias != nil {
+			node = node.Alias
+		} else {
+			return node
+		}
+	}
+}
+
+// CloneNode create a deep copy of a node and all of its children.
+func CloneNode(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+
+	result := *node
+	if node.Content != nil {
+		result.Content = make([]*Node, len(node.Content))
+		for i, ch := range node.Content {
+			result.Content[i] = CloneNode(ch)
+		}
+	}
+	return &result
+}
+
+// ValueToNode convert a go value to a node, nodes are returned unchanged.
+func ValueToNode(value interface{}) (*Node, error) {
+	if node, ok := value.(*Node); ok {
+		return node, nil
+	}
+
+	node := &Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
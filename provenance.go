@@ -0,0 +1,144 @@
+package yaml
+
+import "sync"
+
+// provenanceTable keep location of each node that is loaded by a loader, so location of nodes could be
+// tracked without touching their comments.
+type provenanceTable struct {
+	lock      sync.RWMutex
+	locations map[*Node]Location
+}
+
+func newProvenanceTable() *provenanceTable {
+	return &provenanceTable{locations: map[*Node]Location{}}
+}
+
+func (t *provenanceTable) Get(node *Node) (Location, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	loc, ok := t.locations[node]
+	return loc, ok
+}
+func (t *provenanceTable) Set(node *Node, loc Location) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.locations[node] = loc
+}
+
+// Copy give ``node`` same location as ``template``, it is used for nodes that are created to replace another node
+func (t *provenanceTable) Copy(template, node *Node) {
+	if loc, ok := t.Get(template); ok {
+		t.Set(node, loc)
+	}
+}
+
+// Track register ``node`` as a node of ``filename`` and return its location, if the node is already tracked
+// only its path will be updated so nodes that are included from other files keep their original file.
+// ``source`` is content of the file, it is nil for nodes that are not read from it.
+func (t *provenanceTable) Track(node *Node, filename, path string, includes *[]Location, source sourceText) Location {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	loc, ok := t.locations[node]
+	if ok {
+		loc.Path = path
+	} else {
+		endLine, endColumn := source.NodeEnd(node)
+		loc = Location{
+			Filename:  filename,
+			Line:      node.Line,
+			Column:    node.Column,
+			EndLine:   endLine,
+			EndColumn: endColumn,
+			Path:      path,
+			includes:  includes,
+		}
+	}
+	t.locations[node] = loc
+	return loc
+}
+
+// NodeLocation return location of ``node`` in the last document that is loaded by the loader(locations
+// are kept until the next load start), nodes that are not loaded by the loader only have their position.
+func (loader *Loader) NodeLocation(node *Node) Location {
+	if loc, ok := loader.provenance.Get(node); ok {
+		return loc
+	}
+	return nodePosition(node)
+}
+
+// NodeFilename return the file that ``node`` is loaded from, see ``NodeLocation``.
+func (loader *Loader) NodeFilename(node *Node) string { return loader.NodeLocation(node).Filename }
+
+// NodeName return path of ``node`` in its document, see ``NodeLocation``.
+func (loader *Loader) NodeName(node *Node) string { return loader.NodeLocation(node).Path }
+
+// NodeFullName return file, position and path of ``node``, see ``NodeLocation``.
+func (loader *Loader) NodeFullName(node *Node) string {
+	if loc, ok := loader.provenance.Get(node); ok {
+		return nodeFullName(loc)
+	}
+	return nodeFullName(nodePosition(node))
+}
+
+// CreateNodeFromTemplate is same as ``CreateNodeFromTemplate`` but the new node keep location of ``template``.
+func (loader *Loader) CreateNodeFromTemplate(template *Node, kind Kind, tag, value string, content []*Node) *Node {
+	node := CreateNodeFromTemplate(template, kind, tag, value, content)
+	loader.provenance.Copy(template, node)
+	return node
+}
+
+// CloneNode is same as ``CloneNode`` but the copy and its children keep location of the original nodes.
+func (loader *Loader) CloneNode(node *Node) *Node {
+	result := CloneNode(node)
+	loader.copyProvenance(node, result)
+	return result
+}
+func (loader *Loader) copyProvenance(node, clone *Node) {
+	if node == nil {
+		return
+	}
+	loader.provenance.Copy(node, clone)
+	for i := range node.Content {
+		loader.copyProvenance(node.Content[i], clone.Content[i])
+	}
+}
+
+// locateErrors complete location of errors in ``err`` that are created by ``NewYamlError``(for example by
+// tags that have no access to the loader) using location of their node.
+func (loader *Loader) locateErrors(err error) {
+	switch e := err.(type) {
+	case YamlErrors:
+		for _, item := range e {
+			loader.locateErrors(item)
+		}
+	case *YamlError:
+		if e.node != nil {
+			if loc, ok := loader.provenance.Get(e.node); ok && e.Filename == "" {
+				if e.Path != "" {
+					loc.Path = e.Path
+				}
+				e.Location = loc
+			}
+		}
+		loader.locateErrors(e.Err)
+	}
+}
+
+// includeSites return location of include nodes that lead to the file that is currently being loaded
+func (loader *Loader) includeSites() *[]Location {
+	var sites []Location
+	for _, frame := range loader.includes {
+		if frame.Site != nil {
+			loc := loader.NodeLocation(frame.Site)
+			loc.includes = nil
+			sites = append(sites, loc)
+		}
+	}
+	if len(sites) == 0 {
+		return nil
+	}
+	return &sites
+}
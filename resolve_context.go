@@ -0,0 +1,130 @@
+package yaml
+
+import (
+	"context"
+	"errors"
+)
+
+// WithContext attach ``ctx`` to the loader, it is passed to tags in their ``ResolveContext`` and loading
+// stops with its error as soon as it is canceled.
+func WithContext(ctx context.Context) LoaderOption {
+	return func(loader *Loader) { loader.ctx = ctx }
+}
+
+// ResolveOptions is the configuration of the loader that is resolving a tag.
+type ResolveOptions struct {
+	MaxIncludeDepth int
+	Roots           []string
+	KnownFields     bool
+	Strict          bool
+	CollectErrors   bool
+	MaxErrors       int
+}
+
+// ResolveContext is the state of the loader at the point that a tag is resolved.
+type ResolveContext struct {
+	Context context.Context
+	Loader  *Loader
+	// Filename is the file that is currently being loaded
+	Filename string
+	// Path is path of the node that is resolved and ParentPath is path of its parent
+	Path       string
+	ParentPath string
+	// Parent is the mapping or sequence that contains the node, it is nil for the root of a document
+	Parent *Node
+	// IncludeStack is files that are currently being loaded, outermost first
+	IncludeStack []string
+	Options      ResolveOptions
+}
+
+// LookupVariable search visible variables of the current scope, see ``Loader.LookupVariable``.
+func (ctx *ResolveContext) LookupVariable(name string) (interface{}, bool) {
+	return ctx.Loader.LookupVariable(name)
+}
+
+// DefineVariable define a variable in the current scope, see ``Loader.DefineVariable``.
+func (ctx *ResolveContext) DefineVariable(name string, value interface{}) {
+	ctx.Loader.DefineVariable(name, value)
+}
+
+// Variables return all variables that are visible in the current scope.
+func (ctx *ResolveContext) Variables() map[string]interface{} { return ctx.Loader.VisibleVariables() }
+
+// ContextTag is a tag that receive a ``ResolveContext`` instead of the raw loader, it must be wrapped by
+// ``WrapContextTag`` to be registered in a ``TagRegistry`` unless it is also a ``Tag``(like ``EnvTag``).
+// the context is only created for tags that implement this interface.
+type ContextTag interface {
+	Names() []string
+	ResolveContext(ctx *ResolveContext, node *Node) (*Node, error)
+}
+
+type contextTagAdapter struct {
+	tag ContextTag
+}
+
+// WrapContextTag convert a ``ContextTag`` to a ``Tag``, so it can be registered in a ``TagRegistry``.
+func WrapContextTag(tag ContextTag) Tag { return contextTagAdapter{tag: tag} }
+
+func (a contextTagAdapter) Names() []string { return a.tag.Names() }
+func (a contextTagAdapter) Resolve(loader *Loader, node *Node) (*Node, error) {
+	return a.tag.ResolveContext(loader.newResolveContext(node), node)
+}
+func (a contextTagAdapter) ResolveContext(ctx *ResolveContext, node *Node) (*Node, error) {
+	return a.tag.ResolveContext(ctx, node)
+}
+
+// Context return the context of the loader, ``context.Background`` if it has none.
+func (loader *Loader) Context() context.Context {
+	if loader.ctx == nil {
+		return context.Background()
+	}
+	return loader.ctx
+}
+
+// resolveTag resolve ``node`` with ``tag``, a ``ResolveContext`` is only created for tags that use it
+func (loader *Loader) resolveTag(tag Tag, node *Node) (*Node, error) {
+	if ct, ok := tag.(ContextTag); ok {
+		return ct.ResolveContext(loader.newResolveContext(node), node)
+	}
+	return tag.Resolve(loader, node)
+}
+
+func (loader *Loader) newResolveContext(node *Node) *ResolveContext {
+	ctx := &ResolveContext{
+		Context:      loader.Context(),
+		Loader:       loader,
+		Path:         loader.NodeLocation(node).Path,
+		IncludeStack: make([]string, len(loader.includes)),
+		Options: ResolveOptions{
+			MaxIncludeDepth: loader.maxIncludeDepth,
+			Roots:           append([]string{}, loader.roots...),
+			KnownFields:     loader.knownFields,
+			Strict:          loader.strict,
+			CollectErrors:   loader.collectErrors,
+			MaxErrors:       loader.maxErrors,
+		},
+	}
+	for i, frame := range loader.includes {
+		ctx.IncludeStack[i] = frame.Filename
+	}
+	if n := len(loader.includes); n != 0 {
+		ctx.Filename = loader.includes[n-1].Filename
+	}
+	if n := len(loader.parents); n != 0 {
+		ctx.Parent = loader.parents[n-1]
+		ctx.ParentPath = loader.NodeLocation(ctx.Parent).Path
+	}
+	return ctx
+}
+
+// checkCanceled return the error of the context of the loader if it is canceled
+func (loader *Loader) checkCanceled(node *Node) error {
+	if err := loader.Context().Err(); err != nil {
+		return NewYamlError(node, err)
+	}
+	return nil
+}
+
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,75 @@
+package yaml
+
+import (
+	"fmt"
+	"os"
+)
+
+// WithRoots confine tags that access files(``IncludeTag``, ``FileTag`` and ``TagGlob``) to ``roots``, any
+// path outside of them(either directly, using ``..`` or through a symlink) will be rejected with a
+// ``SandboxError``.
+func WithRoots(roots ...string) LoaderOption {
+	return func(loader *Loader) { loader.roots = append(loader.roots, roots...) }
+}
+
+// SandboxError is the error that is reported when a tag try to access a path outside of the roots of the
+// loader.
+type SandboxError struct {
+	Location
+	Path string
+}
+
+func (e *SandboxError) Error() string { return fmt.Sprintf("%s: %s", e.Location, e.message()) }
+
+// message is the error message without its location
+func (e *SandboxError) message() string {
+	return fmt.Sprintf("%v: %q is outside of the allowed roots", Err_SandboxViolation, e.Path)
+}
+func (e *SandboxError) Unwrap() error   { return Err_SandboxViolation }
+func (e *SandboxError) Code() ErrorCode { return Code_SandboxViolation }
+
+// CheckPath check that ``path``(that is written in ``node``) is inside roots of the loader and return
+// a ``SandboxError`` if it is not.
+func (loader *Loader) CheckPath(node *Node, path string) error {
+	if len(loader.roots) == 0 {
+		return nil
+	}
+
+	fullpath, err := loader.fs.Abs(path)
+	if err != nil {
+		return NewYamlError(node, err)
+	}
+	realpath, err := loader.fs.EvalSymlinks(fullpath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return NewYamlError(node, err)
+		}
+		realpath = fullpath
+	}
+
+	for _, root := range loader.roots {
+		if fullroot, err := loader.fs.Abs(root); err != nil {
+			return NewYamlError(node, err)
+		} else if !isPathInside(loader.fs, fullroot, fullpath) {
+			continue
+		} else if realroot, err := loader.fs.EvalSymlinks(fullroot); err != nil {
+			return NewYamlError(node, err)
+		} else if isPathInside(loader.fs, realroot, realpath) {
+			return nil
+		}
+	}
+
+	return &SandboxError{Location: loader.NodeLocation(node), Path: path}
+}
+
+// isPathInside check if ``path`` is ``root`` or one of its descendants, both paths must be absolute and clean
+func isPathInside(fsys FileSystem, root, path string) bool {
+	for path != root {
+		parent := fsys.Dir(path)
+		if parent == path {
+			return false
+		}
+		path = parent
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package yaml
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaDraft is the JSON Schema dialect that is supported by ``Schema``
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a subset of JSON Schema(draft 2020-12) that is used to validate resolved documents.
+// supported keywords are ``type``, ``enum``, ``const``, numeric and string bounds, ``pattern``(RE2 syntax),
+// array bounds, ``items``, ``prefixItems``, ``uniqueItems``, ``contains``, object bounds, ``properties``,
+// ``patternProperties``, ``additionalProperties``, ``propertyNames``, ``required``, ``allOf``, ``anyOf``,
+// ``oneOf``, ``not``, ``if``/``then``/``else`` and ``$ref`` to the root or to ``$defs`` of the root,
+// annotations(``title``, ``description``, ``default``, ``format``, ...) are kept but never validated.
+// ``const: null`` is not supported, use ``type: "null"`` instead. a schema that is set by ``WithSchema``
+// apply to every document that the loader load, documents with different schemas need a loader each or
+// ``ValidateNode``.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Default     interface{}        `json:"default,omitempty"`
+	Deprecated  bool               `json:"deprecated,omitempty"`
+
+	Type  SchemaTypes   `json:"type,omitempty"`
+	Enum  []interface{} `json:"enum,omitempty"`
+	Const interface{}   `json:"const,omitempty"`
+
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	Format    string `json:"format,omitempty"`
+
+	Items       *Schema   `json:"items,omitempty"`
+	PrefixItems []*Schema `json:"prefixItems,omitempty"`
+	Contains    *Schema   `json:"contains,omitempty"`
+	MinItems    *int      `json:"minItems,omitempty"`
+	MaxItems    *int      `json:"maxItems,omitempty"`
+	UniqueItems bool      `json:"uniqueItems,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+	If    *Schema   `json:"if,omitempty"`
+	Then  *Schema   `json:"then,omitempty"`
+	Else  *Schema   `json:"else,omitempty"`
+
+	// boolean is set for boolean schemas(``true`` accept anything and ``false`` reject anything)
+	boolean *bool
+}
+
+// BoolSchema return a schema that accept every value(``true``) or reject every value(``false``)
+func BoolSchema(accept bool) *Schema {
+	return &Schema{boolean: &accept}
+}
+
+// ParseSchema parse a JSON Schema document.
+func ParseSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// schemaFields is used to (un)marshal fields of a schema without recursion into its methods
+type schemaFields Schema
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var accept bool
+	if err := json.Unmarshal(data, &accept); err == nil {
+		*s = Schema{boolean: &accept}
+		return nil
+	}
+	return json.Unmarshal(data, (*schemaFields)(s))
+}
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.boolean != nil {
+		return json.Marshal(*s.boolean)
+	}
+	return json.Marshal((*schemaFields)(s))
+}
+
+// SchemaTypes is value of the ``type`` keyword, that may be a single type or a list of types
+type SchemaTypes []string
+
+func (t *SchemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = SchemaTypes{single}
+		return nil
+	}
+
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings: %w", err)
+	}
+	*t = types
+	return nil
+}
+func (t SchemaTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
@@ -0,0 +1,215 @@
+package yaml
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// GenerateSchema create a JSON Schema for the type of ``target``(a value that could be passed to
+// ``Loader.Load``), fields follow the rules of the yaml decoder(``yaml`` tag and ``inline``).
+// a ``doc`` tag of a field is used as its description and an ``enum`` tag is a comma separated list of
+// values that are allowed for it, ``required:"true"`` and ``default`` tags are reported as ``required``
+// and ``default`` keywords of the schema. named structs are defined in ``$defs``, so recursive types are supported.
+func GenerateSchema(target interface{}) (*Schema, error) {
+	if target == nil {
+		return nil, fmt.Errorf("missing target: %w", Err_InvalidValue)
+	}
+
+	root := indirectType(reflect.TypeOf(target))
+	g := schemaGenerator{root: root, defs: map[string]*Schema{}, names: map[reflect.Type]string{}}
+	schema, err := g.TypeSchema(root)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Schema = SchemaDraft
+	if len(g.defs) != 0 {
+		schema.Defs = g.defs
+	}
+	return schema, nil
+}
+
+type schemaGenerator struct {
+	root  reflect.Type
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+func (g *schemaGenerator) TypeSchema(typ reflect.Type) (*Schema, error) {
+	typ = indirectType(typ)
+	if typ == durationType {
+		return &Schema{Type: SchemaTypes{"string"}, Pattern: `^[-+]?([0-9]*(\.[0-9]*)?[a-zµ]+)+$`}, nil
+	} else if typ == timeType {
+		return &Schema{Type: SchemaTypes{"string"}, Format: "date-time"}, nil
+	} else if reflect.PtrTo(typ).Implements(unmarshalerType) {
+		// content of custom unmarshalers is unknown
+		return &Schema{}, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: SchemaTypes{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: SchemaTypes{"integer"}}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		minimum := 0.0
+		return &Schema{Type: SchemaTypes{"integer"}, Minimum: &minimum}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: SchemaTypes{"number"}}, nil
+	case reflect.String:
+		return &Schema{Type: SchemaTypes{"string"}}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			// a []byte is decoded from a (binary) string
+			return &Schema{Type: SchemaTypes{"string"}}, nil
+		}
+		items, err := g.TypeSchema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := &Schema{Type: SchemaTypes{"array"}, Items: items}
+		if typ.Kind() == reflect.Array {
+			// yaml.v3 only decode sequences with the exact length of the array
+			length := typ.Len()
+			schema.MinItems, schema.MaxItems = &length, &length
+		}
+		return schema, nil
+
+	case reflect.Map:
+		values, err := g.TypeSchema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: SchemaTypes{"object"}, AdditionalProperties: values}, nil
+
+	case reflect.Struct:
+		return g.StructRef(typ)
+
+	default:
+		return nil, fmt.Errorf("can't generate a schema for type %s: %w", typ, Err_InvalidValue)
+	}
+}
+
+// StructRef return a reference to the definition of a named struct, anonymous structs are defined in place
+func (g *schemaGenerator) StructRef(typ reflect.Type) (*Schema, error) {
+	if typ == g.root {
+		if _, ok := g.names[typ]; ok {
+			return &Schema{Ref: "#"}, nil
+		}
+		g.names[typ] = ""
+		return g.StructSchema(typ)
+	} else if typ.Name() == "" {
+		return g.StructSchema(typ)
+	}
+
+	if name, ok := g.names[typ]; ok {
+		return &Schema{Ref: "#/$defs/" + name}, nil
+	}
+
+	name := typ.Name()
+	for i := 2; g.defs[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", typ.Name(), i)
+	}
+	g.names[typ] = name
+	// reserve the name before the definition is generated, so recursive references find it
+	g.defs[name] = &Schema{}
+
+	schema, err := g.StructSchema(typ)
+	if err != nil {
+		return nil, err
+	}
+	g.defs[name] = schema
+	return &Schema{Ref: "#/$defs/" + name}, nil
+}
+
+func (g *schemaGenerator) StructSchema(typ reflect.Type) (*Schema, error) {
+	info, err := getStructInfo(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{Type: SchemaTypes{"object"}, Properties: make(map[string]*Schema, len(info.Fields))}
+	for _, field := range info.Fields {
+		property, err := g.FieldSchema(field.Field)
+		if err != nil {
+			return nil, err
+		}
+		if field.Default != nil {
+			if err = UnmarshalYaml([]byte(*field.Default), &property.Default); err != nil {
+				return nil, fmt.Errorf("invalid default value(%s) of field %s: %w", *field.Default,
+					field.Field.Name, Err_InvalidValue)
+			}
+			property.Default = normalizeSchemaValue(property.Default)
+		}
+		if field.Required {
+			schema.Required = append(schema.Required, field.Key)
+		}
+		schema.Properties[field.Key] = property
+	}
+
+	if info.InlineMap != nil {
+		values, err := g.TypeSchema(indirectType(typ.FieldByIndex(info.InlineMap).Type).Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema.AdditionalProperties = values
+	}
+	return schema, nil
+}
+
+func (g *schemaGenerator) FieldSchema(field reflect.StructField) (*Schema, error) {
+	schema, err := g.TypeSchema(field.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc := field.Tag.Get("doc"); doc != "" {
+		// references are created for each use, so this never change the shared definition
+		schema.Description = doc
+	}
+
+	if enum, ok := field.Tag.Lookup("enum"); ok {
+		// enum of a list apply to its items
+		target, typ := schema, indirectType(field.Type)
+		for target.Items != nil {
+			target, typ = target.Items, indirectType(typ.Elem())
+		}
+		for _, item := range strings.Split(enum, ",") {
+			value, err := parseEnumValue(typ, strings.TrimSpace(item))
+			if err != nil {
+				return nil, fmt.Errorf("invalid enum value(%s) of field %s: %w", item, field.Name, err)
+			}
+			target.Enum = append(target.Enum, value)
+		}
+	}
+	return schema, nil
+}
+
+func parseEnumValue(typ reflect.Type, s string) (interface{}, error) {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if typ == durationType {
+			return s, nil
+		}
+		return strconv.ParseInt(s, 0, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.ParseUint(s, 0, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(s, 64)
+	default:
+		return s, nil
+	}
+}
@@ -0,0 +1,479 @@
+package yaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WithSchema validate each loaded document against ``schema`` after its tags are resolved and before it is
+// decoded, violations are reported at the node that caused them, even if it is loaded from another file.
+// the schema is used for every document of the loader(``Load``, ``LoadAll`` and ``StreamDecoder``), there
+// is no way to change it for a single call.
+func WithSchema(schema *Schema) LoaderOption {
+	return func(loader *Loader) { loader.schema = schema }
+}
+
+// ValidateNode validate a resolved node against ``schema`` and return a ``YamlErrors`` with an error
+// for each violation.
+func ValidateNode(node *Node, schema *Schema) error {
+	if errs := validateSchema(node, schema, nodePosition); len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateSchema validate ``node`` against ``schema``, ``locate`` find location of nodes that violate it
+func validateSchema(node *Node, schema *Schema, locate func(*Node) Location) YamlErrors {
+	v := schemaValidator{root: schema, locate: locate, active: map[schemaVisit]bool{}}
+	return v.Validate(node, schema)
+}
+
+var (
+	schemaPatternsLock sync.Mutex
+	schemaPatterns     = map[string]*regexp.Regexp{}
+)
+
+func compileSchemaPattern(pattern string) (*regexp.Regexp, error) {
+	schemaPatternsLock.Lock()
+	defer schemaPatternsLock.Unlock()
+
+	if re, ok := schemaPatterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	schemaPatterns[pattern] = re
+	return re, nil
+}
+
+type schemaValidator struct {
+	root   *Schema
+	locate func(*Node) Location
+	// active is the schemas that are currently validating each node, a schema that is reached again for
+	// the same node is a reference cycle that never consume any input
+	active map[schemaVisit]bool
+}
+
+type schemaVisit struct {
+	schema *Schema
+	node   *Node
+}
+
+func (v *schemaValidator) violation(node *Node, format string, a ...interface{}) *YamlError {
+	return &YamlError{
+		Location: v.locate(node),
+		Err:      fmt.Errorf("%s: %w", fmt.Sprintf(format, a...), Err_SchemaViolation),
+	}
+}
+
+// keyViolation report a violation at a key, keys are not part of the path so the path of its value is used
+func (v *schemaValidator) keyViolation(key, value *Node, format string, a ...interface{}) *YamlError {
+	err := v.violation(key, format, a...)
+	err.Path = v.locate(value).Path
+	return err
+}
+
+func (v *schemaValidator) IsValid(node *Node, schema *Schema) bool {
+	return len(v.Validate(node, schema)) == 0
+}
+
+func (v *schemaValidator) Validate(node *Node, schema *Schema) YamlErrors {
+	node = UnwrapNode(node)
+	visit := schemaVisit{schema: schema, node: node}
+	if v.active[visit] {
+		return YamlErrors{v.violation(node, "invalid schema, reference cycle %q never consume any input",
+			schema.Ref)}
+	}
+	v.active[visit] = true
+	defer delete(v.active, visit)
+
+	if schema.boolean != nil {
+		if !*schema.boolean {
+			return YamlErrors{v.violation(node, "value is not allowed")}
+		}
+		return nil
+	}
+
+	var errs YamlErrors
+	if schema.Ref != "" {
+		if ref, err := v.ResolveRef(schema.Ref); err != nil {
+			return YamlErrors{v.violation(node, "%v", err)}
+		} else {
+			errs = append(errs, v.Validate(node, ref)...)
+		}
+	}
+
+	typ := nodeSchemaType(node)
+	if len(schema.Type) != 0 && !schemaTypeMatches(schema.Type, typ, node) {
+		return append(errs, v.violation(node, "expected %s, got %s", strings.Join(schema.Type, " or "), typ))
+	}
+
+	if len(schema.Enum) != 0 {
+		value, found := nodeSchemaValue(node), false
+		for _, item := range schema.Enum {
+			if reflect.DeepEqual(value, normalizeSchemaValue(item)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, v.violation(node, "value must be one of %s", schemaJSON(schema.Enum)))
+		}
+	}
+	if schema.Const != nil && !reflect.DeepEqual(nodeSchemaValue(node), normalizeSchemaValue(schema.Const)) {
+		errs = append(errs, v.violation(node, "value must be %s", schemaJSON(schema.Const)))
+	}
+
+	switch typ {
+	case "integer", "number":
+		errs = append(errs, v.ValidateNumber(node, schema)...)
+	case "string":
+		errs = append(errs, v.ValidateString(node, schema)...)
+	case "array":
+		errs = append(errs, v.ValidateArray(node, schema)...)
+	case "object":
+		errs = append(errs, v.ValidateObject(node, schema)...)
+	}
+	return append(errs, v.ValidateComposition(node, schema)...)
+}
+
+// ResolveRef resolve a reference to the root schema(``#``) or to a definition of it(``#/$defs/name``)
+func (v *schemaValidator) ResolveRef(ref string) (*Schema, error) {
+	if ref == "#" {
+		return v.root, nil
+	} else if strings.HasPrefix(ref, "#/$defs/") {
+		name := strings.NewReplacer("~1", "/", "~0", "~").Replace(strings.TrimPrefix(ref, "#/$defs/"))
+		if schema, ok := v.root.Defs[name]; ok {
+			return schema, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported or unknown schema reference %q", ref)
+}
+
+func (v *schemaValidator) ValidateNumber(node *Node, schema *Schema) YamlErrors {
+	value, err := ToFloat(node)
+	if err != nil {
+		return YamlErrors{v.violation(node, "invalid number %q", node.Value)}
+	}
+
+	var errs YamlErrors
+	if schema.Minimum != nil && value < *schema.Minimum {
+		errs = append(errs, v.violation(node, "%v is less than minimum %v", value, *schema.Minimum))
+	}
+	if schema.Maximum != nil && value > *schema.Maximum {
+		errs = append(errs, v.violation(node, "%v is greater than maximum %v", value, *schema.Maximum))
+	}
+	if schema.ExclusiveMinimum != nil && value <= *schema.ExclusiveMinimum {
+		errs = append(errs, v.violation(node, "%v must be greater than %v", value, *schema.ExclusiveMinimum))
+	}
+	if schema.ExclusiveMaximum != nil && value >= *schema.ExclusiveMaximum {
+		errs = append(errs, v.violation(node, "%v must be less than %v", value, *schema.ExclusiveMaximum))
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		if q := value / *schema.MultipleOf; math.Abs(q-math.Round(q)) > 1e-9 {
+			errs = append(errs, v.violation(node, "%v is not a multiple of %v", value, *schema.MultipleOf))
+		}
+	}
+	return errs
+}
+
+func (v *schemaValidator) ValidateString(node *Node, schema *Schema) YamlErrors {
+	var errs YamlErrors
+	length := utf8.RuneCountInString(node.Value)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		errs = append(errs, v.violation(node, "length %d is less than minLength %d", length, *schema.MinLength))
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		errs = append(errs, v.violation(node, "length %d is greater than maxLength %d", length, *schema.MaxLength))
+	}
+	if schema.Pattern != "" {
+		if re, err := compileSchemaPattern(schema.Pattern); err != nil {
+			errs = append(errs, v.violation(node, "invalid pattern %q: %v", schema.Pattern, err))
+		} else if !re.MatchString(node.Value) {
+			errs = append(errs, v.violation(node, "%q does not match pattern %q", node.Value, schema.Pattern))
+		}
+	}
+	return errs
+}
+
+func (v *schemaValidator) ValidateArray(node *Node, schema *Schema) YamlErrors {
+	var errs YamlErrors
+	count := len(node.Content)
+	if schema.MinItems != nil && count < *schema.MinItems {
+		errs = append(errs, v.violation(node, "%d item(s) is less than minItems %d", count, *schema.MinItems))
+	}
+	if schema.MaxItems != nil && count > *schema.MaxItems {
+		errs = append(errs, v.violation(node, "%d item(s) is more than maxItems %d", count, *schema.MaxItems))
+	}
+
+	for i, item := range node.Content {
+		if i < len(schema.PrefixItems) {
+			errs = append(errs, v.Validate(item, schema.PrefixItems[i])...)
+		} else if schema.Items != nil {
+			errs = append(errs, v.Validate(item, schema.Items)...)
+		}
+	}
+
+	if schema.Contains != nil {
+		found := false
+		for _, item := range node.Content {
+			if v.IsValid(item, schema.Contains) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, v.violation(node, "no item matches the schema of contains"))
+		}
+	}
+
+	if schema.UniqueItems {
+		values := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			values[i] = nodeSchemaValue(item)
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(values[i], values[j]) {
+					errs = append(errs, v.violation(item, "item is a duplicate of item %d", j))
+					break
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func (v *schemaValidator) ValidateObject(node *Node, schema *Schema) YamlErrors {
+	var errs YamlErrors
+	pairs := mappingPairs(node)
+	count := len(pairs)
+	if schema.MinProperties != nil && count < *schema.MinProperties {
+		errs = append(errs, v.violation(node, "%d propertie(s) is less than minProperties %d", count,
+			*schema.MinProperties))
+	}
+	if schema.MaxProperties != nil && count > *schema.MaxProperties {
+		errs = append(errs, v.violation(node, "%d propertie(s) is more than maxProperties %d", count,
+			*schema.MaxProperties))
+	}
+
+	present := make(map[string]bool, count)
+	for _, pair := range pairs {
+		key, value := pair[0], pair[1]
+		present[key.Value] = true
+
+		if schema.PropertyNames != nil {
+			errs = append(errs, v.Validate(key, schema.PropertyNames)...)
+		}
+
+		evaluated := false
+		if property, ok := schema.Properties[key.Value]; ok {
+			errs = append(errs, v.Validate(value, property)...)
+			evaluated = true
+		}
+		for pattern, property := range schema.PatternProperties {
+			if re, err := compileSchemaPattern(pattern); err != nil {
+				errs = append(errs, v.violation(key, "invalid pattern %q: %v", pattern, err))
+			} else if re.MatchString(key.Value) {
+				errs = append(errs, v.Validate(value, property)...)
+				evaluated = true
+			}
+		}
+
+		if evaluated || schema.AdditionalProperties == nil {
+			continue
+		} else if additional := schema.AdditionalProperties; additional.boolean != nil && !*additional.boolean {
+			errs = append(errs, v.keyViolation(key, value, "property %s is not allowed", key.Value))
+		} else {
+			errs = append(errs, v.Validate(value, additional)...)
+		}
+	}
+
+	for _, name := range schema.Required {
+		if !present[name] {
+			errs = append(errs, v.violation(node, "missing required property %s", name))
+		}
+	}
+	return errs
+}
+
+func (v *schemaValidator) ValidateComposition(node *Node, schema *Schema) YamlErrors {
+	var errs YamlErrors
+	for _, sub := range schema.AllOf {
+		errs = append(errs, v.Validate(node, sub)...)
+	}
+
+	if len(schema.AnyOf) != 0 {
+		found := false
+		for _, sub := range schema.AnyOf {
+			if v.IsValid(node, sub) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, v.violation(node, "value does not match any schema of anyOf"))
+		}
+	}
+
+	if len(schema.OneOf) != 0 {
+		matches := 0
+		for _, sub := range schema.OneOf {
+			if v.IsValid(node, sub) {
+				matches++
+			}
+		}
+		if matches == 0 {
+			errs = append(errs, v.violation(node, "value does not match any schema of oneOf"))
+		} else if matches > 1 {
+			errs = append(errs, v.violation(node, "value matches %d schemas of oneOf, it must match exactly one",
+				matches))
+		}
+	}
+
+	if schema.Not != nil && v.IsValid(node, schema.Not) {
+		errs = append(errs, v.violation(node, "value must not match the schema of not"))
+	}
+
+	if schema.If != nil {
+		if v.IsValid(node, schema.If) {
+			if schema.Then != nil {
+				errs = append(errs, v.Validate(node, schema.Then)...)
+			}
+		} else if schema.Else != nil {
+			errs = append(errs, v.Validate(node, schema.Else)...)
+		}
+	}
+	return errs
+}
+
+// nodeSchemaType return the JSON type of a resolved node
+func nodeSchemaType(node *Node) string {
+	switch node.Kind {
+	case MappingNode:
+		return "object"
+	case SequenceNode:
+		return "array"
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func schemaTypeMatches(types []string, typ string, node *Node) bool {
+	for _, t := range types {
+		if t == typ || (t == "number" && typ == "integer") {
+			return true
+		} else if t == "integer" && typ == "number" {
+			// a number without a fractional part is an integer in JSON Schema
+			if value, err := ToFloat(node); err == nil && value == math.Trunc(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nodeSchemaValue decode a node to the value that JSON decoder produce for it, so it can be compared
+// with values of a schema
+func nodeSchemaValue(node *Node) interface{} {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil
+	}
+	return normalizeSchemaValue(value)
+}
+
+// normalizeSchemaValue convert all numbers to ``float64`` and all mappings to ``map[string]interface{}``
+func normalizeSchemaValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, string, float64:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = normalizeSchemaValue(item)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			result[k] = normalizeSchemaValue(item)
+		}
+		return result
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			result[fmt.Sprint(k)] = normalizeSchemaValue(item)
+		}
+		return result
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32:
+		return rv.Float()
+	default:
+		return value
+	}
+}
+
+func schemaJSON(value interface{}) string {
+	if data, err := json.Marshal(value); err == nil {
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
+
+// mappingPairs return key/value pairs of a mapping, pairs of merged mappings(``<<``) are included unless
+// their key is already defined
+func mappingPairs(node *Node) [][2]*Node {
+	var pairs, merged [][2]*Node
+	defined := map[string]bool{}
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.ShortTag() != "!!merge" {
+			pairs = append(pairs, [2]*Node{key, value})
+			defined[key.Value] = true
+		} else if value = UnwrapNode(value); value.Kind == SequenceNode {
+			for _, item := range value.Content {
+				if item = UnwrapNode(item); item.Kind == MappingNode {
+					merged = append(merged, mappingPairs(item)...)
+				}
+			}
+		} else if value.Kind == MappingNode {
+			merged = append(merged, mappingPairs(value)...)
+		}
+	}
+
+	for _, pair := range merged {
+		if !defined[pair[0].Value] {
+			pairs = append(pairs, pair)
+			defined[pair[0].Value] = true
+		}
+	}
+	return pairs
+}
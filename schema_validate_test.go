@@ -0,0 +1,110 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["name"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 2},
+		"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+		"mode": {"enum": ["dev", "prod"]},
+		"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true},
+		"id": {"type": "string", "pattern": "^[a-z]+$"},
+		"server": {"$ref": "#/$defs/server"}
+	},
+	"$defs": {
+		"server": {"type": "object", "required": ["host"], "properties": {"host": {"type": "string"}}}
+	}
+}`
+
+func TestValidateNode(t *testing.T) {
+	schema, err := ParseSchema([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		content    string
+		violations []string
+	}{
+		{"valid", "name: app\nport: 80\nmode: dev\ntags: [a, b]\nid: abc\nserver: {host: x}\n", nil},
+		{"wrong type", "name: app\nport: http\n", []string{"2:7"}},
+		{"missing required", "port: 80\n", []string{"1:1"}},
+		{"additional property", "name: app\nother: 1\n", []string{"2:1"}},
+		{"out of range", "name: app\nport: 0\n", []string{"2:7"}},
+		{"enum", "name: app\nmode: test\n", []string{"2:7"}},
+		{"short string", "name: a\n", []string{"1:7"}},
+		{"duplicate items", "name: app\ntags: [a, a]\n", []string{"2:11"}},
+		{"array item", "name: app\ntags: [a, 1]\n", []string{"2:11"}},
+		{"pattern", "name: app\nid: ABC\n", []string{"2:5"}},
+		{"reference", "name: app\nserver: {port: 1}\n", []string{"2:9"}},
+		{"several", "port: x\nmode: y\n", []string{"1:7", "2:7", "1:1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc Node
+			if err := UnmarshalYaml([]byte(tt.content), &doc); err != nil {
+				t.Fatal(err)
+			}
+
+			err := ValidateNode(&doc, schema)
+			var violations []string
+			var errs YamlErrors
+			if errors.As(err, &errs) {
+				for _, e := range errs {
+					if !errors.Is(e, Err_SchemaViolation) {
+						t.Errorf("%v is not a schema violation", e)
+					}
+					violations = append(violations, fmt.Sprintf("%d:%d", e.Line, e.Column))
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(violations, tt.violations) {
+				t.Errorf("violations at %v, want %v(%v)", violations, tt.violations, err)
+			}
+		})
+	}
+}
+
+func TestValidateNodeReferenceCycle(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{"$ref": "#/$defs/a", "$defs": {"a": {"$ref": "#/$defs/a"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc Node
+	if err := UnmarshalYaml([]byte("a: 1\n"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateNode(&doc, schema); !errors.Is(err, Err_SchemaViolation) {
+		t.Errorf("error is %v, want a schema violation", err)
+	}
+}
+
+func TestWithSchemaLocatesIncludedNodes(t *testing.T) {
+	schema, err := ParseSchema([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := fstest.MapFS{"server.yaml": {Data: []byte("port: 1\n")}}
+	_, err = testLoad(fsys, "name: app\nserver: !include server.yaml\n", WithSchema(schema))
+
+	var errs YamlErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("error is %v, want a single violation", err)
+	}
+	if loc := errs[0].Location; loc.Filename != "/server.yaml" || loc.Line != 1 || loc.Path != ".server" {
+		t.Errorf("violation is at %s, want .server@/server.yaml(1:1)", loc)
+	}
+}
@@ -0,0 +1,56 @@
+package yaml
+
+// PushScope push a new variable scope, variables that are defined after this call are only visible until
+// the matching ``PopScope``. ``variables`` is the initial content of the scope and may be nil.
+func (loader *Loader) PushScope(variables map[string]interface{}) {
+	scope := make(map[string]interface{}, len(variables))
+	for k, v := range variables {
+		scope[k] = v
+	}
+	loader.scopes = append(loader.scopes, scope)
+}
+
+// PopScope remove innermost variable scope that is pushed by ``PushScope``.
+func (loader *Loader) PopScope() {
+	loader.scopes = loader.scopes[:len(loader.scopes)-1]
+}
+
+// DefineVariable define a variable in the innermost scope, if there is no scope it will be defined as a
+// global variable.
+func (loader *Loader) DefineVariable(name string, value interface{}) {
+	if n := len(loader.scopes); n != 0 {
+		loader.scopes[n-1][name] = value
+	} else {
+		loader.Variables[name] = value
+	}
+}
+
+// DefineGlobalVariable define a variable that is visible everywhere, regardless of current scope.
+func (loader *Loader) DefineGlobalVariable(name string, value interface{}) {
+	loader.Variables[name] = value
+}
+
+// LookupVariable search scopes from innermost to outermost and then global variables for a variable.
+func (loader *Loader) LookupVariable(name string) (interface{}, bool) {
+	for i := len(loader.scopes) - 1; i >= 0; i-- {
+		if value, ok := loader.scopes[i][name]; ok {
+			return value, true
+		}
+	}
+	value, ok := loader.Variables[name]
+	return value, ok
+}
+
+// VisibleVariables return all variables that are visible in current scope, inner variables shadow outer ones.
+func (loader *Loader) VisibleVariables() map[string]interface{} {
+	result := make(map[string]interface{}, len(loader.Variables))
+	for k, v := range loader.Variables {
+		result[k] = v
+	}
+	for _, scope := range loader.scopes {
+		for k, v := range scope {
+			result[k] = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package yaml
+
+import (
+	"bytes"
+	"io"
+)
+
+// WithResetVariables reset ``Variables`` of the loader to their initial value before each document of a
+// multi-document stream, so variables that are exported by a document are not visible to the next ones.
+func WithResetVariables() LoaderOption {
+	return func(loader *Loader) { loader.resetVariables = true }
+}
+
+// LoadAll load every document of a ``---`` separated stream, ``target`` is called with index of each
+// document to get the value that the document will be decoded into, values of empty documents are left
+// unchanged. variables that are exported by a document are visible to the next ones unless the loader
+// is created with ``WithResetVariables``.
+func (loader *Loader) LoadAll(content []byte, filename string, target func(index int) interface{}) error {
+	initial := copyVariables(loader.Variables)
+	return loader.loadRoot(func() error {
+		dec := NewYamlDecoder(bytes.NewReader(content))
+		return loader.loadDocuments(dec, filename, newSourceText(content), nil, func(index int) interface{} {
+			if index != 0 && loader.resetVariables {
+				loader.Variables = copyVariables(initial)
+			}
+			return target(index)
+		})
+	})
+}
+func (loader *Loader) LoadAllPath(path string, target func(index int) interface{}) error {
+	if content, err := loader.fs.ReadFile(path); err != nil {
+		return err
+	} else if fullpath, err := loader.fs.Abs(path); err != nil {
+		return err
+	} else {
+		return loader.LoadAll(content, fullpath, target)
+	}
+}
+
+// IncludeDocuments is same as ``IncludePath`` but it load every document of the file, ``target`` is called
+// with index of each document to get the value that the document will be decoded into.
+func (loader *Loader) IncludeDocuments(node *Node, path string, target func(index int) interface{}) error {
+	content, fullpath, err := loader.readInclude(node, path)
+	if err != nil {
+		return err
+	}
+	return loader.loadRoot(func() error {
+		dec := NewYamlDecoder(bytes.NewReader(content))
+		return loader.loadDocuments(dec, fullpath, newSourceText(content), node, target)
+	})
+}
+
+// loadDocuments load every document of ``dec``, ``source`` is content of the stream or nil if it is unknown
+func (loader *Loader) loadDocuments(dec *Decoder, filename string, source sourceText, site *Node,
+	target func(index int) interface{}) error {
+	for index := 0; ; index++ {
+		if err := loader.loadDocument(dec, filename, source, site, func() interface{} { return target(index) }); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// loadDocument load the next document of ``dec``, ``io.EOF`` is returned at the end of the stream. ``target``
+// is called for every document, even an empty one(like the one after a trailing ``---``) that is a null
+// document in yaml, so indexes match the stream, but nothing is decoded into the target of an empty document.
+func (loader *Loader) loadDocument(dec *Decoder, filename string, source sourceText, site *Node,
+	target func() interface{}) error {
+	var doc Node
+	if err := dec.Decode(&doc); err != nil {
+		return err
+	}
+
+	loader.pushInclude(filename, site)
+	defer loader.popInclude()
+
+	loader.PushScope(nil)
+	defer loader.PopScope()
+
+	cl := loader.newContentLoader(filename, source, target())
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	return cl.UnmarshalYAML(doc.Content[0])
+}
+
+func copyVariables(variables map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(variables))
+	for k, v := range variables {
+		result[k] = v
+	}
+	return result
+}
+
+// StreamDecoder read documents of a stream one at a time and resolve their tags, it is created by
+// ``Loader.NewDecoder``.
+type StreamDecoder struct {
+	loader      *Loader
+	dec         *Decoder
+	filename    string
+	knownFields bool
+	index       int
+	variables   map[string]interface{}
+}
+
+// NewDecoder create a decoder that read documents from ``r``, ``filename`` is the logical name of the
+// stream(for example ``<stdin>``) that is reported in locations and is used to resolve relative paths.
+func (loader *Loader) NewDecoder(r io.Reader, filename string) *StreamDecoder {
+	return &StreamDecoder{
+		loader:    loader,
+		dec:       NewYamlDecoder(r),
+		filename:  filename,
+		variables: copyVariables(loader.Variables),
+	}
+}
+
+// KnownFields ensure that keys of mappings that are decoded into a struct match a field of the struct.
+func (d *StreamDecoder) KnownFields(enable bool) { d.knownFields = enable }
+
+// Decode resolve tags of the next document of the stream and decode it into ``target``, it return
+// ``io.EOF`` when there is no more document.
+func (d *StreamDecoder) Decode(target interface{}) error {
+	if d.index != 0 && d.loader.resetVariables {
+		d.loader.Variables = copyVariables(d.variables)
+	}
+
+	knownFields := d.loader.knownFields
+	d.loader.knownFields = knownFields || d.knownFields
+	defer func() { d.loader.knownFields = knownFields }()
+
+	err := d.loader.loadRoot(func() error {
+		return d.loader.loadDocument(d.dec, d.filename, nil, nil, func() interface{} { return target })
+	})
+	if err != io.EOF {
+		d.index++
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package yaml
+
+import "fmt"
+
+// WithStrict reject keys that have no matching field in the target struct and mappings that have duplicate
+// keys, duplicates are checked after tags are resolved so keys that are spliced by tags are checked too.
+func WithStrict() LoaderOption {
+	return func(loader *Loader) { loader.strict = true }
+}
+
+// findDuplicateKeys report every scalar key of a mapping in ``node`` that is already defined in the
+// same mapping, merge keys(``<<``) are ignored.
+func (loader *Loader) findDuplicateKeys(node *Node) YamlErrors {
+	var errs YamlErrors
+	if node.Kind == MappingNode {
+		keys := map[string]*Node{}
+		for i := 0; i < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Kind != ScalarNode || key.ShortTag() == "!!merge" {
+				continue
+			}
+
+			if first, ok := keys[key.Value]; !ok {
+				keys[key.Value] = key
+			} else {
+				// keys are not part of the path, so the error is reported at the key with the path of its value
+				loc := loader.NodeLocation(key)
+				loc.Path = loader.NodeLocation(value).Path
+				errs = append(errs, &YamlError{
+					Location: loc,
+					Err: fmt.Errorf("key %s is already defined at %s:%d: %w", key.Value, loader.NodeFilename(first),
+						first.Line, Err_DuplicateKey),
+				})
+			}
+		}
+	}
+
+	for _, ch := range node.Content {
+		errs = append(errs, loader.findDuplicateKeys(ch)...)
+	}
+	return errs
+}
@@ -31,7 +31,7 @@ type StringListReader struct {
 func (reader *StringListReader) AcceptObject() bool { return reader.ItemChild != "" }
 
 func (reader *StringListReader) ReadStringList(loader *Loader, node *Node) (list *StringList, failedNode *Node, err error) {
-	var context readStringListContext
+	context := readStringListContext{Reader: reader, Loader: loader}
 
 	context.Init()
 
@@ -61,7 +61,7 @@ type readStringListContext struct {
 }
 
 func (c *readStringListContext) Init() {
-	c.List = &StringList{}
+	c.List = &StringList{Data: map[string]interface{}{}}
 }
 func (c *readStringListContext) IsFailed() bool { return c.Err != nil }
 func (c *readStringListContext) Result() (*StringList, *Node, error) {
@@ -116,10 +116,10 @@ func (c *readStringListContext) ReadPossiblyConcatenatedValuesFromString(s strin
 			for _, item := range strings.Split(s, sep) {
 				c.AppendStringNode(item, node)
 			}
+			return
 		}
-	} else {
-		c.List.Values = []StringNode{{Value: s, Node: node}}
 	}
+	c.AppendStringNode(s, node)
 }
 func (c *readStringListContext) ReadScalarNode(node *Node) {
 	s := c.TryReadDefaultValueFromString(node.Value)
@@ -135,7 +135,7 @@ func (c *readStringListContext) ReadSequenceNodeValue(node *Node) {
 }
 func (c *readStringListContext) ReadSequenceNode(node *Node) {
 	for _, n := range node.Content {
-		c.ReadSequenceNode(n)
+		c.ReadSequenceNodeValue(n)
 		if c.Err != nil {
 			return
 		}
@@ -188,7 +188,7 @@ func (c *readStringListContext) ReadMappingNode(node *Node) {
 		return
 	}
 
-	for i := 0; i < len(node.Content); i++ {
+	for i := 0; i < len(node.Content); i += 2 {
 		key := node.Content[i].Value
 		c.ReadMappingNodeValue(key, node.Content[i+1])
 		if c.Err != nil {
@@ -1,5 +1,9 @@
 package yaml
 
+// Tag is a yaml tag that the loader resolve, tags that need more than the loader(current file, parent
+// node, context, ...) may implement ``ContextTag`` too, see ``WrapContextTag``. the context is opt-in, a
+// ``ResolveContext`` is only created for tags that implement ``ContextTag`` and other tags(including most
+// of the standard tags) are called with the loader as before.
 type Tag interface {
 	Names() []string
 	Resolve(loader *Loader, node *Node) (*Node, error)
@@ -0,0 +1,158 @@
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mehdi-roozitalab/core_utils"
+)
+
+var (
+	envNames      = []string{CreateTagName("env"), "!env"}
+	envTypes      = []string{"str", "int", "float", "bool"}
+	envItemReader = StringListReader{
+		ItemSeparators:   []string{"|"},
+		DefaultSeparator: ':',
+		ItemChild:        "items",
+		DefaultChild:     "default",
+		ExtraNodeParser: func(reader *StringListReader, list *StringList, nodeName string, node *Node) error {
+			switch nodeName {
+			case "required":
+				if b, err := ToBool(node); err != nil {
+					return err
+				} else {
+					list.Data["required"] = b
+					return nil
+				}
+			case "type":
+				if s, err := ToString(node); err != nil {
+					return err
+				} else if !core_utils.StringArrayContains(envTypes, s) {
+					return fmt.Errorf("invalid type(%s), type must be one of %v: %w", s, envTypes, Err_InvalidValue)
+				} else {
+					list.Data["type"] = s
+					return nil
+				}
+			default:
+				return Err_InvalidChild
+			}
+		},
+	}
+)
+
+// EnvTag tag that will be applied to a string or a sequence of strings and will read value of the first
+// environment variable that exists, for example ``!env DB_HOST|PGHOST:localhost``.
+// in its mapping form it also accept ``required`` that cause an error when none of the variables exists
+// and there is no default and ``type``(str, int, float or bool) that change type of the result.
+// if none of the variables exists and they are not required the result is null.
+type EnvTag struct{}
+
+func (tag EnvTag) Names() []string { return envNames }
+func (tag EnvTag) Resolve(loader *Loader, node *Node) (*Node, error) {
+	return tag.ResolveContext(loader.newResolveContext(node), node)
+}
+func (tag EnvTag) ResolveContext(ctx *ResolveContext, node *Node) (*Node, error) {
+	if !IsTag(tag, node.Tag) {
+		return node, nil
+	}
+
+	reader := envReader{Loader: ctx.Loader, SourceNode: node}
+	if err := reader.ReadOptions(); err != nil {
+		return nil, err
+	} else {
+		return reader.Resolve()
+	}
+}
+
+type envReader struct {
+	Loader     *Loader
+	SourceNode *Node
+	Names      *StringList
+	Required   bool
+	Type       string
+}
+
+func (r *envReader) ReadOptions() error {
+	names, failedNode, err := envItemReader.ReadStringList(r.Loader, r.SourceNode)
+	if err != nil {
+		return NewYamlError(failedNode, err)
+	}
+	r.Names = names
+
+	if len(r.Names.Values) == 0 {
+		return NewYamlErrorf(r.SourceNode, "at least one environment variable is required: %w", Err_MissingItems)
+	}
+	for _, name := range r.Names.Values {
+		if name.Value == "" {
+			return NewYamlErrorf(name.Node, "empty environment variable name is not valid: %w", Err_InvalidValue)
+		}
+	}
+
+	if required, ok := r.Names.Data["required"]; ok {
+		r.Required = required.(bool)
+	}
+	if typ, ok := r.Names.Data["type"]; ok {
+		r.Type = typ.(string)
+	} else {
+		r.Type = "str"
+	}
+	return nil
+}
+func (r *envReader) GetResult(value string) (*Node, error) {
+	switch r.Type {
+	case "int":
+		if n, err := strconv.ParseInt(value, 0, 64); err != nil {
+			return nil, NewYamlError(r.SourceNode, causeErrorf(Err_InvalidValue, err, "invalid integer value(%s)", value))
+		} else {
+			return CreateNodeFromTemplate(r.SourceNode, ScalarNode, "!!int", strconv.FormatInt(n, 10), nil), nil
+		}
+	case "float":
+		if f, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, NewYamlError(r.SourceNode, causeErrorf(Err_InvalidValue, err, "invalid float value(%s)", value))
+		} else {
+			return CreateNodeFromTemplate(r.SourceNode, ScalarNode, "!!float", strconv.FormatFloat(f, 'g', -1, 64), nil), nil
+		}
+	case "bool":
+		v := strings.ToLower(value)
+		if core_utils.StringArrayContains(trueValues, v) {
+			return CreateNodeFromTemplate(r.SourceNode, ScalarNode, "!!bool", "true", nil), nil
+		} else if core_utils.StringArrayContains(falseValues, v) {
+			return CreateNodeFromTemplate(r.SourceNode, ScalarNode, "!!bool", "false", nil), nil
+		} else {
+			return nil, NewYamlErrorf(r.SourceNode, "invalid boolean value(%s): %w", value, Err_InvalidValue)
+		}
+	default:
+		return StringToScalarNode(r.SourceNode, value), nil
+	}
+}
+func (r *envReader) LoadDefault() (*Node, error) {
+	if r.Names.DefaultValue == nil {
+		if r.Required {
+			return nil, NewYamlErrorf(r.SourceNode,
+				"none of the environment variables exists and no default value is provided: %w", Err_MissingEnvVariable)
+		}
+		return CreateNodeFromTemplate(r.SourceNode, ScalarNode, "!!null", "", nil), nil
+	}
+
+	if r.Names.DefaultValue.Node == nil {
+		return r.GetResult(r.Names.DefaultValue.Value)
+	}
+
+	var s string
+	if resolved, err := r.Loader.ResolveTags(r.Names.DefaultValue.Node); err != nil {
+		return nil, err
+	} else if err = resolved.Decode(&s); err != nil {
+		return nil, NewYamlError(resolved, err)
+	} else {
+		return r.GetResult(s)
+	}
+}
+func (r *envReader) Resolve() (*Node, error) {
+	for _, name := range r.Names.Values {
+		if value, ok := r.Loader.LookupEnv(name.Value); ok {
+			return r.GetResult(value)
+		}
+	}
+	return r.LoadDefault()
+}
@@ -1,10 +1,6 @@
 package yaml
 
-import (
-	"os"
-
-	"github.com/mehdi-roozitalab/core_utils"
-)
+import "os"
 
 var (
 	fileNames       = []string{CreateTagName("file"), "!file"}
@@ -28,8 +24,6 @@ var (
 	}
 )
 
-const err_NoFile = core_utils.ConstError("none of the files exists and no default value is provided")
-
 // FileTag tag that will applied to a string or a sequence of strings and will read the content of the
 // file or files.
 // By default it will read first existing file and return a ScalarNode of type string but you may set
@@ -38,7 +32,7 @@ type FileTag struct{}
 
 func (tag FileTag) Names() []string { return fileNames }
 func (tag FileTag) Resolve(loader *Loader, node *Node) (*Node, error) {
-	if IsTag(tag, node.Tag) {
+	if !IsTag(tag, node.Tag) {
 		return node, nil
 	}
 
@@ -62,7 +56,7 @@ type fileReader struct {
 }
 
 func (f *fileReader) ReadFileNames() error {
-	includeList, failedNode, err := includePathReader.ReadStringList(f.Loader, f.SourceNode)
+	includeList, failedNode, err := fileItemsReader.ReadStringList(f.Loader, f.SourceNode)
 	if err != nil {
 		return NewYamlError(failedNode, err)
 	}
@@ -71,11 +65,11 @@ func (f *fileReader) ReadFileNames() error {
 }
 func (f *fileReader) ValidateFiles() error {
 	if len(f.Files.Values) == 0 {
-		return NewYamlConstError(f.SourceNode, "at least one path is required")
+		return NewYamlErrorf(f.SourceNode, "at least one path is required: %w", Err_MissingItems)
 	}
 	for _, path := range f.Files.Values {
 		if path.Value == "" {
-			return NewYamlConstError(path.Node, "empty path is not valid")
+			return NewYamlErrorf(path.Node, "empty path is not valid: %w", Err_EmptyPath)
 		}
 	}
 	return nil
@@ -122,11 +116,17 @@ func (f *fileReader) LoadDefault() (*Node, error) {
 		return f.GetResult()
 	}
 
-	return nil, NewYamlError(f.SourceNode, err_NoFile)
+	return nil, NewYamlErrorf(f.SourceNode, "none of the files exists and no default value is provided: %w",
+		Err_MissingFile)
 }
 func (f *fileReader) Resolve() (*Node, error) {
 	for _, file := range f.Files.Values {
-		if content, err := os.ReadFile(file.Value); err != nil {
+		path := f.Loader.ResolvePath(file.Node, file.Value)
+		if err := f.Loader.CheckPath(file.Node, path); err != nil {
+			return nil, err
+		}
+
+		if content, err := f.Loader.GetFileSystem().ReadFile(path); err != nil {
 			if !os.IsNotExist(err) || f.ShouldReadAll {
 				return nil, NewYamlErrorf(file.Node, "failed to read the file at %q: %w", file.Value, err)
 			}
@@ -12,7 +12,7 @@ func (tag TagFlattern) Resolve(loader *Loader, node *Node) (*Node, error) {
 	}
 
 	if node.Kind != SequenceNode {
-		return nil, NewYamlConstError(node, "flattern should only applied to a sequence")
+		return nil, NewYamlErrorf(node, "flattern should only applied to a sequence: %w", Err_BadNodeKind)
 	}
 
 	content := make([]*Node, 0, len(node.Content))
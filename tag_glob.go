@@ -1,7 +1,5 @@
 package yaml
 
-import "path/filepath"
-
 var globNames = []string{CreateTagName("glob"), "!glob"}
 
 // TagGlob tag that will be applied to a string and return list of all files that match specified glob pattern
@@ -14,12 +12,17 @@ func (tag TagGlob) Resolve(loader *Loader, node *Node) (*Node, error) {
 	}
 
 	if node.Kind != ScalarNode {
-		return nil, NewYamlErrorf(node, "%s must applied to a string value", node.Tag)
+		return nil, NewYamlErrorf(node, "%s must applied to a string value: %w", node.Tag, Err_BadNodeKind)
 	}
 
-	if matches, err := filepath.Glob(node.Value); err != nil {
+	if matches, err := loader.GetFileSystem().Glob(loader.ResolveGlob(node, node.Value)); err != nil {
 		return nil, NewYamlError(node, err)
 	} else {
+		for _, match := range matches {
+			if err = loader.CheckPath(node, match); err != nil {
+				return nil, err
+			}
+		}
 		return StringListToSequenceNode(node, matches), nil
 	}
 }
@@ -1,6 +1,8 @@
 package yaml
 
 import (
+	"errors"
+	"fmt"
 	"os"
 
 	"github.com/mehdi-roozitalab/core_utils"
@@ -12,20 +14,53 @@ var (
 		ItemSeparators: []string{"|", "&"},
 		ItemChild:      "items",
 		ExtraNodeParser: func(reader *StringListReader, list *StringList, nodeName string, node *Node) error {
-			if nodeName == "all" {
+			switch nodeName {
+			case "all":
 				if b, err := ToBool(node); err != nil {
 					return err
 				} else {
 					list.Data["all"] = b
 					return nil
 				}
-			} else {
+			case "vars":
+				if node.Kind != MappingNode {
+					return fmt.Errorf("vars must be a mapping: %w", Err_BadNodeKind)
+				}
+				vars := make(map[string]interface{}, len(node.Content)/2)
+				for i := 0; i < len(node.Content); i += 2 {
+					vars[node.Content[i].Value] = node.Content[i+1]
+				}
+				list.Data["vars"] = vars
+				return nil
+			case "documents":
+				if b, err := ToBool(node); err != nil {
+					return err
+				} else {
+					list.Data["documents"] = b
+					return nil
+				}
+			case "document":
+				if index, err := ToInt(node); err != nil {
+					return err
+				} else if index < 0 {
+					return fmt.Errorf("document index must not be negative: %w", Err_InvalidValue)
+				} else {
+					list.Data["document"] = int(index)
+					return nil
+				}
+			default:
 				return Err_InvalidChild
 			}
 		},
 	}
 )
 
+// IncludeTag tag that will be applied to a path or a list of paths and will be replaced with content of
+// the first file that exists or with a sequence of all of them when ``all`` is true.
+// in its mapping form it also accept ``vars``, a mapping of variables that are only visible while the
+// included files are resolved, so a single fragment may be included many times with different parameters.
+// by default only the first document of a multi-document file is included, ``documents: true`` include
+// all of them as a sequence and ``document: N`` include the document at index N.
 type IncludeTag struct{}
 
 func (tag IncludeTag) Names() []string { return includeNames }
@@ -51,6 +86,14 @@ func (f *includeFragment) UnmarshalYAML(node *Node) error {
 	return nil
 }
 
+// Node return the loaded node or a null node in place of ``site`` if the fragment is an empty document
+func (f *includeFragment) Node(site *Node) *Node {
+	if f.node == nil {
+		return CreateNodeFromTemplate(site, ScalarNode, "!!null", "", nil)
+	}
+	return f.node
+}
+
 type fragmentLoader struct {
 	Loader           *Loader
 	SourceNode       *Node
@@ -58,6 +101,9 @@ type fragmentLoader struct {
 	LoadedPaths      []string
 	IncludeList      *StringList
 	ShouldIncludeAll core_utils.Bool3
+	Vars             map[string]interface{}
+	Documents        bool
+	Document         int
 }
 
 func (fl *fragmentLoader) ReadIncludePaths() error {
@@ -70,11 +116,11 @@ func (fl *fragmentLoader) ReadIncludePaths() error {
 }
 func (fl *fragmentLoader) ValidateIncludePaths() error {
 	if len(fl.IncludeList.Values) == 0 {
-		return NewYamlConstError(fl.SourceNode, "at least one include path is required")
+		return NewYamlErrorf(fl.SourceNode, "at least one include path is required: %w", Err_MissingItems)
 	}
 	for _, path := range fl.IncludeList.Values {
 		if path.Value == "" {
-			return NewYamlConstError(path.Node, "empty include path is not valid")
+			return NewYamlErrorf(path.Node, "empty include path is not valid: %w", Err_EmptyPath)
 		}
 	}
 	return nil
@@ -91,11 +137,47 @@ func (fl *fragmentLoader) ReadShouldIncludeAll() core_utils.Bool3 {
 func (fl *fragmentLoader) IsPathLoaded(path string) bool {
 	return core_utils.StringArrayContains(fl.LoadedPaths, path)
 }
+func (fl *fragmentLoader) IncludePath(node *Node, path string, f *includeFragment) error {
+	fl.Loader.PushScope(fl.Vars)
+	defer fl.Loader.PopScope()
+
+	path = fl.Loader.ResolvePath(node, path)
+	if !fl.Documents && fl.Document == 0 {
+		return fl.Loader.IncludePath(node, path, f)
+	}
+
+	var documents []*includeFragment
+	err := fl.Loader.IncludeDocuments(node, path, func(int) interface{} {
+		documents = append(documents, &includeFragment{})
+		return documents[len(documents)-1]
+	})
+	if err != nil {
+		return err
+	}
+
+	if fl.Documents {
+		content := make([]*Node, len(documents))
+		for i, document := range documents {
+			content[i] = document.Node(node)
+		}
+		f.node = CreateNodeFromTemplate(node, SequenceNode, "!!seq", "", content)
+	} else if fl.Document >= len(documents) {
+		return fmt.Errorf("%s has %d document(s), there is no document at index %d: %w", path, len(documents),
+			fl.Document, Err_InvalidValue)
+	} else {
+		f.node = documents[fl.Document].Node(node)
+	}
+	return nil
+}
 func (fl *fragmentLoader) LoadPath(node *Node, path string) error {
 	var f includeFragment
-	if err := fl.Loader.LoadPath(path, &f); err == nil {
+	if err := fl.IncludePath(node, path, &f); err == nil {
 		fl.LoadedPaths = append(fl.LoadedPaths, path)
 		fl.LoadedNodes = append(fl.LoadedNodes, f.node)
+	} else if errors.Is(err, Err_IncludeCycle) || errors.Is(err, Err_MaxIncludeDepth) ||
+		errors.Is(err, Err_SandboxViolation) || isYamlError(err) {
+		// errors of the included file already have their location and include chain
+		return err
 	} else if !os.IsNotExist(err) || fl.ShouldIncludeAll.IsTrue() {
 		return NewYamlErrorf(node, "failed to load the file from %s: %w", path, err)
 	}
@@ -109,6 +191,18 @@ func (fl *fragmentLoader) ReadOptions() error {
 		return err
 	} else {
 		fl.ShouldIncludeAll = fl.ReadShouldIncludeAll()
+		if vars, ok := fl.IncludeList.Data["vars"]; ok {
+			fl.Vars = vars.(map[string]interface{})
+		}
+		if documents, ok := fl.IncludeList.Data["documents"]; ok {
+			fl.Documents = documents.(bool)
+		}
+		if document, ok := fl.IncludeList.Data["document"]; ok {
+			if fl.Documents {
+				return NewYamlErrorf(fl.SourceNode, "document may not be used with documents: %w", Err_InvalidValue)
+			}
+			fl.Document = document.(int)
+		}
 		return nil
 	}
 }
@@ -116,7 +210,7 @@ func (fl *fragmentLoader) ReadOptions() error {
 func (fl *fragmentLoader) GetResult() (*Node, error) {
 	if len(fl.LoadedNodes) == 0 {
 		if fl.ShouldIncludeAll.IsTrue() {
-			return nil, NewYamlConstError(fl.SourceNode, "failed to load any of the included path")
+			return nil, NewYamlErrorf(fl.SourceNode, "failed to load any of the included path: %w", Err_MissingFile)
 		}
 		fl.SourceNode.Value = ""
 		fl.SourceNode.Tag = "!!nil"
@@ -0,0 +1,118 @@
+package yaml
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestIncludeTag(t *testing.T) {
+	fsys := fstest.MapFS{
+		"conf/a.yaml":      {Data: []byte("a: 1\n")},
+		"conf/b.yaml":      {Data: []byte("b: 2\n")},
+		"conf/nested.yaml": {Data: []byte("n: !include a.yaml\n")},
+		"conf/param.yaml":  {Data: []byte("port: !var port\n")},
+		"conf/multi.yaml":  {Data: []byte("x: 1\n---\nx: 2\n")},
+		"conf/cycle.yaml":  {Data: []byte("c: !include cycle.yaml\n")},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    interface{}
+		err     error
+	}{
+		{name: "path", content: "v: !include conf/a.yaml\n", want: map[string]interface{}{"a": 1}},
+		{
+			name:    "relative to the including file",
+			content: "v: !include conf/nested.yaml\n",
+			want:    map[string]interface{}{"n": map[string]interface{}{"a": 1}},
+		},
+		{name: "first existing file", content: "v: !include conf/x.yaml|conf/b.yaml\n", want: map[string]interface{}{"b": 2}},
+		{
+			name:    "all files",
+			content: "v: !include conf/a.yaml&conf/b.yaml\n",
+			want:    []interface{}{map[string]interface{}{"a": 1}, map[string]interface{}{"b": 2}},
+		},
+		// an include that found no file is replaced with an empty value
+		{name: "missing optional file", content: "v: !include conf/x.yaml\n", want: ""},
+		{name: "missing required file", content: "v: !include {items: [conf/x.yaml], all: true}\n", err: fs.ErrNotExist},
+		{
+			name:    "vars",
+			content: "v: !include {items: [conf/param.yaml], vars: {port: 80}}\n",
+			want:    map[string]interface{}{"port": 80},
+		},
+		{name: "vars are scoped", content: "v: !include {items: [conf/param.yaml], vars: {port: 80}}\nw: !var port\n", err: Err_UnknownVariable},
+		{name: "document", content: "v: !include {items: [conf/multi.yaml], document: 1}\n", want: map[string]interface{}{"x": 2}},
+		{
+			name:    "documents",
+			content: "v: !include {items: [conf/multi.yaml], documents: true}\n",
+			want:    []interface{}{map[string]interface{}{"x": 1}, map[string]interface{}{"x": 2}},
+		},
+		{name: "missing document", content: "v: !include {items: [conf/multi.yaml], document: 2}\n", err: Err_InvalidValue},
+		{name: "cycle", content: "v: !include conf/cycle.yaml\n", err: Err_IncludeCycle},
+		{name: "empty path", content: "v: !include {items: ['']}\n", err: Err_EmptyPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := testLoad(fsys, tt.content)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("error is %v, want %v", err, tt.err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := value.(map[string]interface{})["v"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("result is %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncludeTagLimits(t *testing.T) {
+	fsys := fstest.MapFS{
+		"conf/1.yaml": {Data: []byte("n: !include 2.yaml\n")},
+		"conf/2.yaml": {Data: []byte("n: !include 3.yaml\n")},
+		"conf/3.yaml": {Data: []byte("n: 3\n")},
+		"secret.yaml": {Data: []byte("s: 1\n")},
+	}
+	if _, err := testLoad(fsys, "v: !include conf/1.yaml\n", WithMaxIncludeDepth(3)); !errors.Is(err, Err_MaxIncludeDepth) {
+		t.Errorf("error is %v, want %v", err, Err_MaxIncludeDepth)
+	} else if _, err = testLoad(fsys, "v: !include conf/1.yaml\n", WithMaxIncludeDepth(4)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var sandboxErr *SandboxError
+	if _, err := testLoad(fsys, "v: !include secret.yaml\n", WithRoots("/conf")); !errors.As(err, &sandboxErr) {
+		t.Errorf("error is %v, want a sandbox error", err)
+	} else if _, err = testLoad(fsys, "v: !include conf/3.yaml\n", WithRoots("/")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestIncludeTagErrorLocation(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sub/x.yaml": {Data: []byte("k: !var missing\n")},
+	}
+	_, err := testLoad(fsys, "a: {b: !include sub/x.yaml}\n")
+
+	var ye *YamlError
+	if !errors.As(err, &ye) {
+		t.Fatalf("error is %v, want a YamlError", err)
+	} else if !errors.Is(err, Err_UnknownVariable) {
+		t.Errorf("error is %v, want %v", err, Err_UnknownVariable)
+	}
+	if ye.Filename != "/sub/x.yaml" || ye.Line != 1 || ye.Column != 4 {
+		t.Errorf("error is at %s, want /sub/x.yaml(1:4)", ye.Location)
+	}
+
+	chain := ye.IncludeChain()
+	if len(chain) != 1 || chain[0].Filename != "/main.yaml" || chain[0].Line != 1 || chain[0].Column != 8 {
+		t.Errorf("include chain is %v, want the include at /main.yaml(1:8)", chain)
+	}
+}
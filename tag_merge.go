@@ -0,0 +1,225 @@
+package yaml
+
+import (
+	"fmt"
+
+	"github.com/mehdi-roozitalab/core_utils"
+)
+
+var (
+	mergeNames          = []string{CreateTagName("merge"), "!merge"}
+	mergeListStrategies = []string{MergeListsReplace, MergeListsAppend, MergeListsByKey}
+)
+
+const (
+	// MergeListsReplace replace a list with the list of the later node
+	MergeListsReplace = "replace"
+	// MergeListsAppend append items of the list of the later node to the list of the former node
+	MergeListsAppend = "append"
+	// MergeListsByKey deep merge items of lists that have same value in their ``key`` field and append others
+	MergeListsByKey = "merge"
+)
+
+// MergeTag tag that will be applied to a sequence of nodes(usually result of ``!include``) and will deep
+// merge them from left to right, so values of later nodes override values of former ones.
+// in its mapping form it accept ``items``(the sequence of nodes), ``lists``(replace, append or merge) that
+// control how lists are merged, ``key`` that is name of the field that identify items when lists are
+// merged by key and ``null_deletes`` that cause a null value to remove the key from the result.
+// each value of the result keeps its own location, so errors point to the node that won the merge.
+type MergeTag struct{}
+
+func (tag MergeTag) Names() []string { return mergeNames }
+func (tag MergeTag) Resolve(loader *Loader, node *Node) (*Node, error) {
+	if !IsTag(tag, node.Tag) {
+		return node, nil
+	}
+
+	merger := nodeMerger{Loader: loader, SourceNode: node, Lists: MergeListsReplace}
+	if err := merger.ReadOptions(); err != nil {
+		return nil, err
+	} else {
+		return merger.Resolve()
+	}
+}
+
+type nodeMerger struct {
+	Loader      *Loader
+	SourceNode  *Node
+	Items       *Node
+	Lists       string
+	Key         string
+	NullDeletes bool
+}
+
+func (m *nodeMerger) ReadOption(name string, node *Node) error {
+	var err error
+	switch name {
+	case "items":
+		m.Items = node
+	case "lists":
+		if m.Lists, err = ToString(node); err != nil {
+			return NewYamlError(node, err)
+		} else if !core_utils.StringArrayContains(mergeListStrategies, m.Lists) {
+			return NewYamlErrorf(node, "invalid list strategy(%s), it must be one of %v: %w", m.Lists,
+				mergeListStrategies, Err_InvalidValue)
+		}
+	case "key":
+		if m.Key, err = ToString(node); err != nil {
+			return NewYamlError(node, err)
+		}
+	case "null_deletes":
+		if m.NullDeletes, err = ToBool(node); err != nil {
+			return NewYamlError(node, err)
+		}
+	default:
+		return NewYamlErrorf(node, "%s is an invalid key: %w", name, Err_InvalidChild)
+	}
+	return nil
+}
+func (m *nodeMerger) ReadOptions() error {
+	switch m.SourceNode.Kind {
+	case SequenceNode:
+		m.Items = m.SourceNode
+
+	case MappingNode:
+		for i := 0; i < len(m.SourceNode.Content); i += 2 {
+			if node, err := m.Loader.ResolveTags(m.SourceNode.Content[i+1]); err != nil {
+				return err
+			} else if err = m.ReadOption(m.SourceNode.Content[i].Value, node); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return NewYamlErrorf(m.SourceNode, "%s may only applied to a sequence or a mapping: %w", m.SourceNode.Tag,
+			Err_BadNodeKind)
+	}
+
+	if m.Items == nil {
+		return NewYamlErrorf(m.SourceNode, "missing items: %w", Err_MissingItems)
+	} else if m.Items.Kind != SequenceNode {
+		return NewYamlErrorf(m.Items, "items must be a sequence: %w", Err_BadNodeKind)
+	} else if m.Lists == MergeListsByKey && m.Key == "" {
+		return NewYamlErrorf(m.SourceNode, "key is required when lists are merged by key: %w", Err_MissingRequiredNode)
+	}
+	return nil
+}
+
+func (m *nodeMerger) MergeMappings(dst, src *Node) *Node {
+	result := m.Loader.CreateNodeFromTemplate(dst, MappingNode, "!!map", "", append([]*Node{}, dst.Content...))
+	for i := 0; i < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+
+		index := -1
+		for j := 0; j < len(result.Content); j += 2 {
+			if result.Content[j].Value == key.Value {
+				index = j
+				break
+			}
+		}
+
+		if m.NullDeletes && IsNullNode(value) {
+			if index != -1 {
+				result.Content = append(result.Content[:index], result.Content[index+2:]...)
+			}
+		} else if index == -1 {
+			result.Content = append(result.Content, key, value)
+		} else {
+			result.Content[index+1] = m.Merge(result.Content[index+1], value)
+		}
+	}
+	return result
+}
+func (m *nodeMerger) ItemKey(node *Node) (string, bool) {
+	if node.Kind != MappingNode {
+		return "", false
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == m.Key && node.Content[i+1].Kind == ScalarNode {
+			return node.Content[i+1].Value, true
+		}
+	}
+	return "", false
+}
+func (m *nodeMerger) MergeSequences(dst, src *Node) *Node {
+	switch m.Lists {
+	case MergeListsAppend:
+		content := append(append([]*Node{}, dst.Content...), src.Content...)
+		return m.Loader.CreateNodeFromTemplate(dst, SequenceNode, "!!seq", "", content)
+
+	case MergeListsByKey:
+		result := m.Loader.CreateNodeFromTemplate(dst, SequenceNode, "!!seq", "", append([]*Node{}, dst.Content...))
+		for _, item := range src.Content {
+			merged := false
+			if key, ok := m.ItemKey(item); ok {
+				for i, existing := range result.Content {
+					if existingKey, ok := m.ItemKey(existing); ok && existingKey == key {
+						result.Content[i] = m.Merge(existing, item)
+						merged = true
+						break
+					}
+				}
+			}
+			if !merged {
+				result.Content = append(result.Content, item)
+			}
+		}
+		return result
+
+	default:
+		return src
+	}
+}
+func (m *nodeMerger) Merge(dst, src *Node) *Node {
+	if dst.Kind == MappingNode && src.Kind == MappingNode {
+		return m.MergeMappings(dst, src)
+	} else if dst.Kind == SequenceNode && src.Kind == SequenceNode {
+		return m.MergeSequences(dst, src)
+	} else {
+		return src
+	}
+}
+
+func (m *nodeMerger) Resolve() (*Node, error) {
+	var result *Node
+	for _, item := range m.Items.Content {
+		node, err := m.Loader.ResolveTags(item)
+		if err != nil {
+			return nil, err
+		} else if node == nil || IsNullNode(node) {
+			continue
+		}
+
+		node = UnwrapNode(node)
+		if result == nil {
+			result = node
+		} else if result.Kind != node.Kind {
+			return nil, NewYamlError(node, fmt.Errorf("can't merge a %s with a %s: %w", kindName(node.Kind),
+				kindName(result.Kind), Err_BadNodeKind))
+		} else {
+			result = m.Merge(result, node)
+		}
+	}
+
+	if result == nil {
+		return CreateNodeFromTemplate(m.SourceNode, ScalarNode, "!!null", "", nil), nil
+	}
+	return result, nil
+}
+
+func kindName(kind Kind) string {
+	switch kind {
+	case DocumentNode:
+		return "document"
+	case SequenceNode:
+		return "sequence"
+	case MappingNode:
+		return "mapping"
+	case ScalarNode:
+		return "scalar"
+	case AliasNode:
+		return "alias"
+	default:
+		return fmt.Sprintf("kind(%d)", kind)
+	}
+}
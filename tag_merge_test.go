@@ -0,0 +1,109 @@
+package yaml
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergeTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    interface{}
+		err     error
+	}{
+		{
+			name:    "deep merge",
+			content: "v: !merge [{a: 1, b: {c: 1}}, {b: {d: 2}}]\n",
+			want:    map[string]interface{}{"a": 1, "b": map[string]interface{}{"c": 1, "d": 2}},
+		},
+		{
+			name:    "later value override",
+			content: "v: !merge [{a: 1, b: 1}, {b: 2}]\n",
+			want:    map[string]interface{}{"a": 1, "b": 2},
+		},
+		{
+			name:    "replace lists",
+			content: "v: !merge [{l: [1, 2]}, {l: [3]}]\n",
+			want:    map[string]interface{}{"l": []interface{}{3}},
+		},
+		{
+			name:    "append lists",
+			content: "v: !merge {lists: append, items: [{l: [1]}, {l: [2]}]}\n",
+			want:    map[string]interface{}{"l": []interface{}{1, 2}},
+		},
+		{
+			name:    "merge lists by key",
+			content: "v: !merge {lists: merge, key: n, items: [{l: [{n: a, x: 1}]}, {l: [{n: a, y: 2}, {n: b}]}]}\n",
+			want: map[string]interface{}{"l": []interface{}{
+				map[string]interface{}{"n": "a", "x": 1, "y": 2},
+				map[string]interface{}{"n": "b"},
+			}},
+		},
+		{
+			name:    "null deletes",
+			content: "v: !merge {null_deletes: true, items: [{a: 1, b: 2}, {b: null}]}\n",
+			want:    map[string]interface{}{"a": 1},
+		},
+		{
+			name:    "null is kept",
+			content: "v: !merge [{a: 1, b: 2}, {b: null}]\n",
+			want:    map[string]interface{}{"a": 1, "b": nil},
+		},
+		{
+			name:    "null items are skipped",
+			content: "v: !merge [null, {a: 1}, null]\n",
+			want:    map[string]interface{}{"a": 1},
+		},
+		{
+			name:    "sequences",
+			content: "v: !merge {lists: append, items: [[1], [2, 3]]}\n",
+			want:    []interface{}{1, 2, 3},
+		},
+		{
+			name:    "empty",
+			content: "v: !merge []\n",
+			want:    nil,
+		},
+		{name: "invalid list strategy", content: "v: !merge {lists: zip, items: []}\n", err: Err_InvalidValue},
+		{name: "missing key", content: "v: !merge {lists: merge, items: []}\n", err: Err_MissingRequiredNode},
+		{name: "missing items", content: "v: !merge {lists: append}\n", err: Err_MissingItems},
+		{name: "items is not a sequence", content: "v: !merge {items: {a: 1}}\n", err: Err_BadNodeKind},
+		{name: "different kinds", content: "v: !merge [{a: 1}, [1]]\n", err: Err_BadNodeKind},
+		{name: "unknown option", content: "v: !merge {items: [], other: 1}\n", err: Err_InvalidChild},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := testLoad(nil, tt.content)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("error is %v, want %v", err, tt.err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := value.(map[string]interface{})["v"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("result is %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeTagKeepLocationOfWinningNode(t *testing.T) {
+	loader := NewLoader(DefaultTagRegistry())
+	var root Node
+	if err := loader.Load([]byte("v: !merge\n  - {a: 1}\n  - {a: 2}\n"), &root, "main.yaml"); err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := FindNode(&root, "v.a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc := loader.NodeLocation(node); loc.Filename != "main.yaml" || loc.Line != 3 || loc.Column != 9 {
+		t.Errorf("location is %s, want main.yaml(3:9)", loc)
+	}
+}
@@ -2,6 +2,7 @@ package yaml
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/mehdi-roozitalab/core_utils"
@@ -12,6 +13,19 @@ type TagRegistry interface {
 	GetTagByName(name string) Tag
 }
 
+// isSameTag check if ``a`` and ``b`` are equal tags, registering a tag again is ignored so code that
+// registered standard tags before they were pre-registered keep working. a tag with the same type but a
+// different value is another tag and conflict with the registered one.
+func isSameTag(a, b Tag) bool {
+	return reflect.DeepEqual(unwrapTag(a), unwrapTag(b))
+}
+func unwrapTag(tag Tag) interface{} {
+	if adapter, ok := tag.(contextTagAdapter); ok {
+		return adapter.tag
+	}
+	return tag
+}
+
 type simpleTagRegistry struct {
 	tags map[string]Tag
 }
@@ -25,7 +39,7 @@ func NewSimpleTagRegistry() TagRegistry {
 func (r *simpleTagRegistry) RegisterTags(tag ...Tag) error {
 	for _, item := range tag {
 		for _, s := range item.Names() {
-			if _, ok := r.tags[s]; ok {
+			if existing, ok := r.tags[s]; ok && !isSameTag(existing, item) {
 				return fmt.Errorf("another tag with same name(%s) already exists", s)
 			}
 		}
@@ -33,7 +47,9 @@ func (r *simpleTagRegistry) RegisterTags(tag ...Tag) error {
 
 	for _, item := range tag {
 		for _, s := range item.Names() {
-			r.tags[s] = item
+			if _, ok := r.tags[s]; !ok {
+				r.tags[s] = item
+			}
 		}
 	}
 
@@ -95,7 +111,7 @@ func NewChildRegistry(parent, child TagRegistry) TagRegistry {
 func (r *childTagRegistry) RegisterTags(tag ...Tag) error {
 	for _, item := range tag {
 		for _, s := range item.Names() {
-			if r.GetTagByName(s) != nil {
+			if existing := r.GetTagByName(s); existing != nil && !isSameTag(existing, item) {
 				return fmt.Errorf("another tag with same name(%s) already exists", s)
 			}
 		}
@@ -110,6 +126,14 @@ func (r *childTagRegistry) GetTagByName(name string) Tag {
 	return r.parent.GetTagByName(name)
 }
 
-var defaultTagRegistry = NewThreadSafeTagRegistry(NewSimpleTagRegistry())
+var defaultTagRegistry TagRegistry
+
+func init() {
+	// tag names are package level variables, so standard tags may only be registered after all of them
+	// are initialized
+	defaultTagRegistry = NewThreadSafeTagRegistry(newStandardTagRegistry())
+}
 
+// DefaultTagRegistry return the process wide registry, it is pre-populated with all tags of ``ProfileFull``.
+// registering one of them again is ignored.
 func DefaultTagRegistry() TagRegistry { return defaultTagRegistry }
@@ -0,0 +1,36 @@
+package yaml
+
+import "testing"
+
+type testTag struct{ name string }
+
+func (tag testTag) Names() []string { return []string{"!test"} }
+func (tag testTag) Resolve(loader *Loader, node *Node) (*Node, error) {
+	return CreateNodeFromTemplate(node, ScalarNode, "!!str", tag.name, nil), nil
+}
+
+func TestRegisterTagsAgain(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []Tag
+		conflict bool
+	}{
+		{name: "standard tag", tags: []Tag{IncludeTag{}}},
+		{name: "same tag", tags: []Tag{testTag{"a"}, testTag{"a"}}},
+		{name: "same type with another value", tags: []Tag{testTag{"a"}, testTag{"b"}}, conflict: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewChildRegistry(DefaultTagRegistry(), NewSimpleTagRegistry())
+			var err error
+			for _, tag := range tt.tags {
+				if err = registry.RegisterTags(tag); err != nil {
+					break
+				}
+			}
+			if conflict := err != nil; conflict != tt.conflict {
+				t.Errorf("error is %v, want a conflict: %v", err, tt.conflict)
+			}
+		})
+	}
+}
@@ -15,12 +15,31 @@ func (tag RenderTemplateTag) Resolve(loader *Loader, node *Node) (*Node, error)
 	}
 
 	if node.Kind != ScalarNode {
-		return nil, NewYamlErrorf(node, "%s tag may only applied to string values", node.Tag)
+		return nil, NewYamlErrorf(node, "%s tag may only applied to string values: %w", node.Tag, Err_BadNodeKind)
 	} else if tmpl, err := template.ParseTextTemplate(node.Value); err != nil {
-		return nil, NewYamlErrorf(node, "failed to parse template: %w", err)
-	} else if s, err := tmpl.Render(loader.Variables); err != nil {
-		return nil, NewYamlErrorf(node, "failed to render template: %w", err)
+		return nil, NewYamlError(node, causeErrorf(Err_Template, err, "failed to parse template"))
+	} else if data, err := templateData(loader.VisibleVariables()); err != nil {
+		return nil, NewYamlError(node, causeErrorf(Err_Template, err, "failed to read variables"))
+	} else if s, err := tmpl.Render(data); err != nil {
+		return nil, NewYamlError(node, causeErrorf(Err_Template, err, "failed to render template"))
 	} else {
 		return StringToScalarNode(node, s), nil
 	}
 }
+
+// templateData convert variables that are stored as nodes to their go value, so they could be used in a template
+func templateData(variables map[string]interface{}) (map[string]interface{}, error) {
+	data := make(map[string]interface{}, len(variables))
+	for k, v := range variables {
+		if node, ok := v.(*Node); ok {
+			var value interface{}
+			if err := node.Decode(&value); err != nil {
+				return nil, err
+			}
+			data[k] = value
+		} else {
+			data[k] = v
+		}
+	}
+	return data, nil
+}
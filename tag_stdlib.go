@@ -0,0 +1,118 @@
+package yaml
+
+import (
+	"fmt"
+	"sync"
+)
+
+// StandardTagsVersion is version of the standard tag library. it will be increased whenever a tag is
+// added to or removed from one of the standard profiles.
+const StandardTagsVersion = 6
+
+const (
+	// ProfileSafe contains tags that neither touch the filesystem nor the process environment.
+	ProfileSafe = "safe"
+	// ProfileTemplate contains the template tag, templates may read the host name, the environment and files.
+	ProfileTemplate = "template"
+	// ProfileFileSystem contains tags that read files or list directories.
+	ProfileFileSystem = "filesystem"
+	// ProfileEnvironment contains tags that read the process environment.
+	ProfileEnvironment = "environment"
+	// ProfileFull contains every tag of the standard library.
+	ProfileFull = "full"
+)
+
+var (
+	safeTags = []Tag{
+		SwitchTag{},
+		DefinetVariableTag{},
+		ExportVariableTag{},
+		VariableTag{},
+		TagFlattern{},
+		MergeTag{},
+	}
+	templateTags = []Tag{
+		RenderTemplateTag{},
+	}
+	fileSystemTags = []Tag{
+		IncludeTag{},
+		FileTag{},
+		TagGlob{},
+	}
+	environmentTags = []Tag{
+		EnvTag{},
+	}
+
+	tagProfilesLock sync.Mutex
+	tagProfiles     = map[string][]Tag{
+		ProfileSafe:        safeTags,
+		ProfileTemplate:    templateTags,
+		ProfileFileSystem:  fileSystemTags,
+		ProfileEnvironment: environmentTags,
+		ProfileFull: append(append(append(append([]Tag{}, safeTags...), templateTags...), fileSystemTags...),
+			environmentTags...),
+	}
+)
+
+// RegisterTagProfile register a named set of tags that later may be used in ``NewProfileTagRegistry``.
+// return an error if another profile with the same name already exists.
+func RegisterTagProfile(name string, tags ...Tag) error {
+	tagProfilesLock.Lock()
+	defer tagProfilesLock.Unlock()
+
+	if _, ok := tagProfiles[name]; ok {
+		return fmt.Errorf("another tag profile with same name(%s) already exists", name)
+	}
+	tagProfiles[name] = append([]Tag{}, tags...)
+	return nil
+}
+
+// ProfileTags return tags of a named profile.
+func ProfileTags(name string) ([]Tag, error) {
+	tagProfilesLock.Lock()
+	defer tagProfilesLock.Unlock()
+
+	if tags, ok := tagProfiles[name]; ok {
+		return append([]Tag{}, tags...), nil
+	}
+	return nil, fmt.Errorf("unknown tag profile(%s)", name)
+}
+
+// NewProfileTagRegistry create a registry that contains the union of tags of all specified profiles,
+// a tag that exists in more than one profile will only be registered once.
+func NewProfileTagRegistry(profiles ...string) (TagRegistry, error) {
+	registry := NewSimpleTagRegistry()
+	for _, profile := range profiles {
+		tags, err := ProfileTags(profile)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tags {
+			if isTagRegistered(registry, tag) {
+				continue
+			}
+			if err = registry.RegisterTags(tag); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return registry, nil
+}
+
+func isTagRegistered(registry TagRegistry, tag Tag) bool {
+	for _, name := range tag.Names() {
+		if registry.GetTagByName(name) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func newStandardTagRegistry() TagRegistry {
+	registry, err := NewProfileTagRegistry(ProfileFull)
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
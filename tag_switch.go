@@ -8,7 +8,7 @@ import (
 )
 
 var (
-	switchNames = []string{CreateTagName("switch"), "switch"}
+	switchNames = []string{CreateTagName("switch"), "switch", "!switch"}
 	trueValues  = []string{"true", "yes", "ok", "1", "y"}
 	falseValues = []string{"false", "no", "0", "n"}
 )
@@ -97,7 +97,7 @@ func (r *switchReader) ValidateSourceNode() error {
 		return NewYamlErrorf(r.SourceNode, "switch tag may only applied to a sequence of cases: %w", Err_BadNodeKind)
 	}
 	if len(r.SourceNode.Content) == 0 {
-		return NewYamlConstError(r.SourceNode, "at least one case is required")
+		return NewYamlErrorf(r.SourceNode, "at least one case is required: %w", Err_MissingItems)
 	}
 	return nil
 }
@@ -109,26 +109,28 @@ func (r *switchReader) ReadSwitchCases() error {
 	r.SwitchCases = make([]switchCase, len(r.SourceNode.Content))
 	for i := range r.SourceNode.Content {
 		if !r.SwitchCases[i].Parse(r.SourceNode.Content[i]) {
-			return NewYamlConstError(r.SourceNode.Content[i], "invalid case")
+			return NewYamlErrorf(r.SourceNode.Content[i], "case must contain case and then or only else: %w",
+				Err_MalformedCase)
 		}
 	}
 	return nil
 }
 func (r *switchReader) MoveElseCaseToEndOfCases() error {
 	var elseCase *switchCase
+	cases := make([]switchCase, 0, len(r.SwitchCases))
 	for i := range r.SwitchCases {
-		if r.SwitchCases[i].Case == nil {
-			if elseCase != nil {
-				return NewYamlConstError(r.SwitchCases[i].Node, "multiple else in a single ")
-			}
-			elseCase = &switchCase{}
-			*elseCase = r.SwitchCases[i]
-			r.SwitchCases = append(r.SwitchCases[:i], r.SwitchCases[i+1:]...)
+		if r.SwitchCases[i].Case != nil {
+			cases = append(cases, r.SwitchCases[i])
+		} else if elseCase != nil {
+			return NewYamlErrorf(r.SwitchCases[i].Node, "multiple else in a single switch: %w", Err_MalformedCase)
+		} else {
+			elseCase = &r.SwitchCases[i]
 		}
 	}
 	if elseCase != nil {
-		r.SwitchCases = append(r.SwitchCases, *elseCase)
+		cases = append(cases, *elseCase)
 	}
+	r.SwitchCases = cases
 	return nil
 }
 func (r *switchReader) ResolveActiveNode() (*Node, error) {
@@ -136,11 +138,11 @@ func (r *switchReader) ResolveActiveNode() (*Node, error) {
 		if match, err := sc.Match(r.Loader); err != nil {
 			return nil, err
 		} else if match {
-			return r.Loader.ResolveTags(sc.Node)
+			return r.Loader.ResolveTags(sc.Then)
 		}
 	}
 
-	return nil, NewYamlConstError(r.SourceNode, "none of the cases match input value")
+	return nil, NewYamlErrorf(r.SourceNode, "none of the cases match input value: %w", Err_NoMatchingCase)
 }
 func (r *switchReader) Resolve() (*Node, error) {
 	if err := r.ValidateSourceNode(); err != nil {
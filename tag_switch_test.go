@@ -0,0 +1,44 @@
+package yaml
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSwitchTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    interface{}
+		err     error
+	}{
+		{name: "first true case", content: "v: !switch [{case: false, then: 1}, {case: true, then: 2}, {case: true, then: 3}]\n", want: 2},
+		{name: "else", content: "v: !switch [{case: false, then: 1}, {else: 2}]\n", want: 2},
+		{name: "else before cases", content: "v: !switch [{else: 3}, {case: true, then: 1}]\n", want: 1},
+		{name: "else before false cases", content: "v: !switch [{else: 3}, {case: false, then: 1}]\n", want: 3},
+		{name: "only else", content: "v: !switch [{else: 3}]\n", want: 3},
+		{name: "mapping value", content: "v: !switch [{case: true, then: {a: 1}}]\n", want: map[string]interface{}{"a": 1}},
+		{name: "multiple else", content: "v: !switch [{else: 1}, {case: true, then: 2}, {else: 3}]\n", err: Err_MalformedCase},
+		{name: "malformed case", content: "v: !switch [{case: true}]\n", err: Err_MalformedCase},
+		{name: "not a sequence", content: "v: !switch {case: true, then: 1}\n", err: Err_BadNodeKind},
+		{name: "no case", content: "v: !switch []\n", err: Err_MissingItems},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := testLoad(nil, tt.content)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("error is %v, want %v", err, tt.err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := value.(map[string]interface{})["v"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("result is %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
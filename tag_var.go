@@ -0,0 +1,36 @@
+package yaml
+
+var varNames = []string{CreateTagName("var"), "!var"}
+
+// VariableTag tag that will be applied to a string like ``name.path[0]`` and will be replaced with a deep
+// copy of the variable(or part of it that is selected by the path), so a variable that is defined by
+// ``DefinetVariableTag`` may be reused with its structure and type.
+type VariableTag struct{}
+
+func (tag VariableTag) Names() []string { return varNames }
+func (tag VariableTag) Resolve(loader *Loader, node *Node) (*Node, error) {
+	if !IsTag(tag, node.Tag) {
+		return node, nil
+	}
+
+	if node.Kind != ScalarNode {
+		return nil, NewYamlErrorf(node, "%s must applied to a string value: %w", node.Tag, Err_BadNodeKind)
+	}
+
+	segments, err := ParseNodePath(node.Value)
+	if err != nil {
+		return nil, NewYamlError(node, err)
+	} else if len(segments) == 0 || segments[0].IsIndex {
+		return nil, NewYamlErrorf(node, "path must start with name of a variable: %w", Err_InvalidPath)
+	}
+
+	if value, ok := loader.LookupVariable(segments[0].Key); !ok {
+		return nil, NewYamlErrorf(node, "%s: %w", segments[0].Key, Err_UnknownVariable)
+	} else if variable, err := ValueToNode(value); err != nil {
+		return nil, NewYamlError(node, err)
+	} else if result, err := FindNodeByPath(variable, segments[1:]); err != nil {
+		return nil, NewYamlErrorf(node, "%s: %w", node.Value, err)
+	} else {
+		return loader.CloneNode(result), nil
+	}
+}
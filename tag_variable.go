@@ -1,7 +1,13 @@
 package yaml
 
-var defineVarNames = []string{CreateTagName("define_var"), "define_var"}
+var (
+	defineVarNames = []string{CreateTagName("define_var"), "define_var", "!define_var"}
+	exportNames    = []string{CreateTagName("export"), "!export"}
+)
 
+// DefinetVariableTag tag that will be applied to a mapping and define each of its keys as a variable, the
+// variables are only visible in the enclosing mapping or sequence(and its children) and will be removed
+// from the result.
 type DefinetVariableTag struct{}
 
 func (tag DefinetVariableTag) Names() []string { return defineVarNames }
@@ -10,20 +16,40 @@ func (tag DefinetVariableTag) Resolve(loader *Loader, node *Node) (*Node, error)
 		return node, nil
 	}
 
+	return nil, defineVariables(loader, node, loader.DefineVariable)
+}
+
+// ExportVariableTag is same as ``DefinetVariableTag`` but defined variables are global and visible
+// everywhere after their definition, including sibling and later included files.
+type ExportVariableTag struct{}
+
+func (tag ExportVariableTag) Names() []string { return exportNames }
+func (tag ExportVariableTag) Resolve(loader *Loader, node *Node) (*Node, error) {
+	if !IsTag(tag, node.Tag) {
+		return node, nil
+	}
+
+	return nil, defineVariables(loader, node, loader.DefineGlobalVariable)
+}
+
+func defineVariables(loader *Loader, node *Node, define func(name string, value interface{})) error {
 	if node.Kind != MappingNode {
-		return nil, NewYamlErrorf(node, "%s may only applied to a mapping node", node.Tag)
+		return NewYamlErrorf(node, "%s may only applied to a mapping node: %w", node.Tag, Err_BadNodeKind)
 	}
 
 	for i := 0; i < len(node.Content); i += 2 {
 		k := node.Content[i].Value
 		var val interface{}
 		if v, err := loader.ResolveTags(node.Content[i+1]); err != nil {
-			return nil, err
+			return err
+		} else if v == nil {
+			// value is removed by its tag(for example a nested ``!define_var``), so there is nothing to define
+			continue
 		} else if err = v.Decode(&val); err != nil {
-			return nil, NewYamlErrorf(v, "failed to parse node's value")
+			return NewYamlError(v, causeErrorf(Err_InvalidValue, err, "failed to parse node's value"))
 		} else {
-			loader.Variables[k] = v
+			define(k, v)
 		}
 	}
-	return nil, nil
+	return nil
 }
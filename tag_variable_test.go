@@ -0,0 +1,84 @@
+package yaml
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVariableScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]interface{}
+		err     error
+	}{
+		{
+			name:    "visible in the enclosing mapping",
+			content: "a: !define_var {x: 1}\nb: !var x\n",
+			want:    map[string]interface{}{"b": 1},
+		},
+		{
+			name:    "visible in children",
+			content: "a: !define_var {x: {y: [1, 2]}}\nb: {c: !var 'x.y[1]'}\n",
+			want:    map[string]interface{}{"b": map[string]interface{}{"c": 2}},
+		},
+		{
+			name:    "inner variables shadow outer ones",
+			content: "a: !define_var {x: 1}\nb: {c: !define_var {x: 2}, d: !var x}\ne: !var x\n",
+			want:    map[string]interface{}{"b": map[string]interface{}{"d": 2}, "e": 1},
+		},
+		{
+			name:    "not visible outside of the enclosing mapping",
+			content: "a: {b: !define_var {x: 1}}\nc: !var x\n",
+			err:     Err_UnknownVariable,
+		},
+		{
+			name:    "not visible outside of the enclosing sequence",
+			content: "a: [!define_var {x: 1}, !var x]\nc: !var x\n",
+			err:     Err_UnknownVariable,
+		},
+		{
+			name:    "exported variables are global",
+			content: "a: {b: !export {x: 1}}\nc: !var x\n",
+			want:    map[string]interface{}{"a": map[string]interface{}{}, "c": 1},
+		},
+		{
+			name:    "nested define_var",
+			content: "a: !define_var {x: !define_var {y: 1}}\nb: 2\n",
+			want:    map[string]interface{}{"b": 2},
+		},
+		{
+			name:    "unknown variable",
+			content: "a: !var x\n",
+			err:     Err_UnknownVariable,
+		},
+		{
+			name:    "unknown path of a variable",
+			content: "a: !define_var {x: {y: 1}}\nb: !var x.z\n",
+			err:     Err_InvalidPath,
+		},
+		{
+			name:    "define_var of a scalar",
+			content: "a: !define_var x\n",
+			err:     Err_BadNodeKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := testLoad(nil, tt.content)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("error is %v, want %v", err, tt.err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(value, tt.want) {
+				t.Errorf("result is %#v, want %#v", value, tt.want)
+			}
+		})
+	}
+}
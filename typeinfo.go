@@ -0,0 +1,161 @@
+package yaml
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fieldInfo is information of a field of a struct as it is seen by yaml decoder
+type fieldInfo struct {
+	Key       string
+	Index     []int
+	OmitEmpty bool
+	Flow      bool
+	// Required is set by ``required:"true"`` and Default is the value of ``default`` tag of the field or nil
+	Required bool
+	Default  *string
+	Field    reflect.StructField
+}
+
+// structInfo is information of fields of a struct as it is seen by yaml decoder, fields of inlined structs
+// are flattened into the struct
+type structInfo struct {
+	Fields      []fieldInfo
+	FieldsByKey map[string]int
+	// InlineMap is index of the field that receive keys that are not a field of the struct, or nil
+	InlineMap []int
+}
+
+func (info *structInfo) FieldByKey(key string) *fieldInfo {
+	if i, ok := info.FieldsByKey[key]; ok {
+		return &info.Fields[i]
+	}
+	return nil
+}
+
+var (
+	structInfoLock  sync.RWMutex
+	structInfoCache = map[reflect.Type]*structInfo{}
+)
+
+// getStructInfo return information of fields of a struct, it follows the rules of the yaml package for
+// keys of the fields(``yaml`` tag, ``omitempty``, ``flow`` and ``inline`` flags)
+func getStructInfo(typ reflect.Type) (*structInfo, error) {
+	structInfoLock.RLock()
+	info, ok := structInfoCache[typ]
+	structInfoLock.RUnlock()
+	if ok {
+		return info, nil
+	}
+
+	info = &structInfo{FieldsByKey: map[string]int{}}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "" && !strings.Contains(string(field.Tag), ":") {
+			tag = string(field.Tag)
+		}
+		if tag == "-" {
+			continue
+		}
+
+		fi := fieldInfo{Index: []int{i}, Field: field, Required: field.Tag.Get("required") == "true"}
+		if value, ok := field.Tag.Lookup("default"); ok {
+			fi.Default = &value
+		}
+		inline := false
+		parts := strings.Split(tag, ",")
+		for _, flag := range parts[1:] {
+			switch flag {
+			case "omitempty":
+				fi.OmitEmpty = true
+			case "flow":
+				fi.Flow = true
+			case "inline":
+				inline = true
+			default:
+				return nil, fmt.Errorf("unsupported flag %q in tag %q of type %s", flag, tag, typ)
+			}
+		}
+
+		if inline {
+			ftype := field.Type
+			for ftype.Kind() == reflect.Ptr {
+				ftype = ftype.Elem()
+			}
+			switch ftype.Kind() {
+			case reflect.Map:
+				info.InlineMap = []int{i}
+			case reflect.Struct:
+				inner, err := getStructInfo(ftype)
+				if err != nil {
+					return nil, err
+				}
+				for _, innerField := range inner.Fields {
+					innerField.Index = append([]int{i}, innerField.Index...)
+					if err = info.addField(typ, innerField); err != nil {
+						return nil, err
+					}
+				}
+				if info.InlineMap == nil && inner.InlineMap != nil {
+					info.InlineMap = append([]int{i}, inner.InlineMap...)
+				}
+			default:
+				return nil, fmt.Errorf("option ,inline may only be used on a struct or map field of type %s", typ)
+			}
+			continue
+		}
+
+		if parts[0] != "" {
+			fi.Key = parts[0]
+		} else {
+			fi.Key = strings.ToLower(field.Name)
+		}
+		if err := info.addField(typ, fi); err != nil {
+			return nil, err
+		}
+	}
+
+	structInfoLock.Lock()
+	structInfoCache[typ] = info
+	structInfoLock.Unlock()
+	return info, nil
+}
+
+func (info *structInfo) addField(typ reflect.Type, fi fieldInfo) error {
+	if _, ok := info.FieldsByKey[fi.Key]; ok {
+		return fmt.Errorf("duplicated key '%s' in struct %s", fi.Key, typ)
+	}
+	info.FieldsByKey[fi.Key] = len(info.Fields)
+	info.Fields = append(info.Fields, fi)
+	return nil
+}
+
+// fieldByIndex is same as ``reflect.Value.FieldByIndex`` but it return an invalid value instead of
+// panic when it reach a nil pointer
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// indirectType remove all pointers from a type
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
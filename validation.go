@@ -0,0 +1,139 @@
+package yaml
+
+import (
+	"errors"
+	"reflect"
+)
+
+// LocationLookup give a validator access to the location of the node that its value is decoded from.
+type LocationLookup interface {
+	// Location return location of the node of the value
+	Location() Location
+	// FieldLocation return location of a node inside the node of the value, ``path`` is a path like
+	// ``servers[0].port``(see ``FindNode``), location of the value is returned if the path does not exist
+	FieldLocation(path string) Location
+	// FieldError attach ``err`` to the location of ``path``, see ``FieldLocation``
+	FieldError(path string, err error) error
+}
+
+// Validator may be implemented by target types to validate themselves after they are decoded, validators
+// are called bottom-up so children are validated before their parents. returned errors are reported at
+// the location of the value unless they are created by ``LocationLookup.FieldError``, they all wrap
+// ``Err_Validation``.
+type Validator interface {
+	ValidateYAML(loc LocationLookup) error
+}
+
+var validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+
+type nodeLocationLookup struct {
+	loader *Loader
+	node   *Node
+}
+
+func (l nodeLocationLookup) Location() Location { return l.loader.NodeLocation(l.node) }
+func (l nodeLocationLookup) FieldLocation(path string) Location {
+	if node, err := FindNode(l.node, path); err == nil {
+		return l.loader.NodeLocation(node)
+	}
+	return l.Location()
+}
+func (l nodeLocationLookup) FieldError(path string, err error) error {
+	return &YamlError{Location: l.FieldLocation(path), Err: &validationError{err: err}}
+}
+
+// validationError mark an error that is returned by a validator, it keeps the message of the error
+type validationError struct {
+	err error
+}
+
+func (e *validationError) Error() string        { return e.err.Error() }
+func (e *validationError) Unwrap() error        { return e.err }
+func (e *validationError) Is(target error) bool { return target == Err_Validation }
+
+// callValidators call validators of ``value`` and all values inside it, bottom-up. ``node`` is the node
+// that ``value`` is decoded from or nil if it is missing, in that case ``site``(the nearest node that
+// exists) is used for locations.
+func (loader *Loader) callValidators(value reflect.Value, node, site *Node) YamlErrors {
+	if node != nil {
+		node = UnwrapNode(node)
+		site = node
+	}
+
+	var errs YamlErrors
+	inner := value
+	for inner.Kind() == reflect.Ptr || inner.Kind() == reflect.Interface {
+		if inner.IsNil() {
+			return nil
+		}
+		inner = inner.Elem()
+	}
+
+	switch inner.Kind() {
+	case reflect.Struct:
+		if info, err := getStructInfo(inner.Type()); err == nil {
+			values := map[string]*Node{}
+			if node != nil && node.Kind == MappingNode {
+				for _, pair := range mappingPairs(node) {
+					values[pair[0].Value] = pair[1]
+				}
+			}
+			for _, field := range info.Fields {
+				if fv := fieldByIndex(inner, field.Index); fv.IsValid() {
+					errs = append(errs, loader.callValidators(fv, values[field.Key], site)...)
+				}
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < inner.Len(); i++ {
+			var item *Node
+			if node != nil && node.Kind == SequenceNode && i < len(node.Content) {
+				item = node.Content[i]
+			}
+			errs = append(errs, loader.callValidators(inner.Index(i), item, site)...)
+		}
+
+	case reflect.Map:
+		if node != nil && node.Kind == MappingNode {
+			for _, pair := range mappingPairs(node) {
+				key := reflect.New(inner.Type().Key())
+				if err := pair[0].Decode(key.Interface()); err != nil {
+					continue
+				}
+				// items of a map are not addressable, so validators with a pointer receiver are called on a copy
+				if item := inner.MapIndex(key.Elem()); item.IsValid() {
+					copied := reflect.New(item.Type()).Elem()
+					copied.Set(item)
+					errs = append(errs, loader.callValidators(copied, pair[1], site)...)
+				}
+			}
+		}
+	}
+
+	var validator Validator
+	if inner.CanAddr() && inner.Addr().Type().Implements(validatorType) {
+		validator = inner.Addr().Interface().(Validator)
+	} else if inner.Type().Implements(validatorType) {
+		validator = inner.Interface().(Validator)
+	}
+	if validator != nil {
+		if err := validator.ValidateYAML(nodeLocationLookup{loader: loader, node: site}); err != nil {
+			errs = append(errs, loader.validationErrors(site, err)...)
+		}
+	}
+	return errs
+}
+
+// validationErrors convert an error of a validator to a list of errors that wrap ``Err_Validation``
+func (loader *Loader) validationErrors(node *Node, err error) YamlErrors {
+	errs := loader.toYamlErrors(node, err)
+	result := make(YamlErrors, len(errs))
+	for i, e := range errs {
+		result[i] = &YamlError{Location: e.Location, Err: e.Err}
+		if !errors.Is(e.Err, Err_Validation) {
+			result[i].Err = &validationError{err: e.Err}
+		}
+	}
+	return result
+}